@@ -1,6 +1,7 @@
 package kettle
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/dghubble/sling"
@@ -34,9 +35,14 @@ type App struct {
 // GetAppList returns a list of all apps on steam
 // https://wiki.teamfortress.com/wiki/WebAPI/GetAppList
 func (s *ISteamAppsService) GetAppList() ([]App, *http.Response, error) {
+	return s.GetAppListWithContext(context.Background())
+}
+
+// GetAppListWithContext is GetAppList with a context for cancellation
+func (s *ISteamAppsService) GetAppListWithContext(ctx context.Context) ([]App, *http.Response, error) {
 	response := new(appListResponse)
 
-	resp, err := s.sling.New().Get("GetAppList/v2/").ReceiveSuccess(response)
+	resp, err := receive(ctx, s.sling.New().Get("GetAppList/v2/"), response, nil)
 
 	return response.AppList.Apps, resp, err
 }
@@ -1,6 +1,8 @@
 package kettle
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -37,3 +39,23 @@ func TestISteamAppsServicegGetAppList(t *testing.T) {
 	assert.Equal(t, int64(5), apps[0].AppID)
 	assert.Equal(t, "Dedicated Server", apps[0].Name)
 }
+
+func TestISteamAppsServiceGetAppListWithContextCanceled(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamApps/GetAppList/v2/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent with a canceled context")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient(httpClient, "")
+	apps, _, err := client.ISteamAppsService.GetAppListWithContext(ctx)
+
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Len(t, apps, 0)
+}
@@ -0,0 +1,339 @@
+package kettle
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dghubble/sling"
+)
+
+// Cache stores responses so requests for data that rarely changes don't
+// have to go to Steam. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+// CacheEntry is a cached response
+type CacheEntry struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	Expires    time.Time   `json:"expires"`
+}
+
+// DefaultCacheTTLs are how long responses of an endpoint are cached. The
+// key is the interface and method, like ISteamApps/GetAppList, or the last
+// part of the path for the store. Endpoints without a TTL aren't cached.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"ISteamUserStats/GetSchemaForGame": 24 * time.Hour,
+	"ISteamApps/GetAppList":            6 * time.Hour,
+	"appdetails":                       time.Hour,
+}
+
+// WithCache caches responses in c using DefaultCacheTTLs
+func WithCache(c Cache) Option {
+	return func(o *options) {
+		o.cache = c
+	}
+}
+
+// WithCacheTTL sets how long responses of endpoint are cached, 0 turns off
+// caching for it. See DefaultCacheTTLs for the endpoint names. A method
+// without the interface, like GetAppList, is used for every interface with
+// that method.
+func WithCacheTTL(endpoint string, ttl time.Duration) Option {
+	return func(o *options) {
+		if o.cacheTTLs == nil {
+			o.cacheTTLs = make(map[string]time.Duration)
+		}
+		o.cacheTTLs[endpoint] = ttl
+	}
+}
+
+// doer wraps doer with the cache if there is one
+func (o *options) doer(doer sling.Doer) sling.Doer {
+	if o.cache == nil {
+		return doer
+	}
+
+	ttls := make(map[string]time.Duration)
+	for endpoint, ttl := range DefaultCacheTTLs {
+		ttls[endpoint] = ttl
+	}
+	// A bare method replaces the defaults for it on every interface, it's
+	// applied before the qualified names so those always win
+	for endpoint, ttl := range o.cacheTTLs {
+		if strings.Contains(endpoint, "/") {
+			continue
+		}
+		for e := range ttls {
+			if strings.HasSuffix(e, "/"+endpoint) {
+				delete(ttls, e)
+			}
+		}
+		ttls[endpoint] = ttl
+	}
+	for endpoint, ttl := range o.cacheTTLs {
+		if strings.Contains(endpoint, "/") {
+			ttls[endpoint] = ttl
+		}
+	}
+
+	return &cacheDoer{
+		doer:  doer,
+		cache: o.cache,
+		ttls:  ttls,
+	}
+}
+
+// cacheDoer answers requests from the cache and stores new responses. An
+// expired entry with an ETag or Last-Modified header is revalidated.
+type cacheDoer struct {
+	doer  sling.Doer
+	cache Cache
+	ttls  map[string]time.Duration
+}
+
+func (d *cacheDoer) Do(req *http.Request) (*http.Response, error) {
+	ttl := d.ttl(req)
+	if req.Method != http.MethodGet || ttl <= 0 {
+		return d.doer.Do(req)
+	}
+
+	key := cacheKey(req)
+	entry, ok := d.cache.Get(key)
+	if ok && time.Now().Before(entry.Expires) {
+		return entry.response(req), nil
+	}
+
+	if ok {
+		req = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := d.doer.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		entry.Expires = time.Now().Add(ttl)
+		d.cache.Set(key, entry)
+		return entry.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	d.cache.Set(key, &CacheEntry{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		Expires:    time.Now().Add(ttl),
+	})
+
+	return resp, nil
+}
+
+// ttl finds the TTL for the endpoint of req
+func (d *cacheDoer) ttl(req *http.Request) time.Duration {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if i > 0 {
+			if ttl, ok := d.ttls[parts[i-1]+"/"+parts[i]]; ok {
+				return ttl
+			}
+		}
+		if ttl, ok := d.ttls[parts[i]]; ok {
+			return ttl
+		}
+	}
+
+	return 0
+}
+
+// cacheKey is the URL of req without the API key
+func cacheKey(req *http.Request) string {
+	u := *req.URL
+	q := u.Query()
+	q.Del("key")
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+func (e *CacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used
+// entry when it's full
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+type memoryItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache returns a MemoryCache holding at most size entries
+func NewMemoryCache(size int) *MemoryCache {
+	return &MemoryCache{
+		size:    size,
+		ll:      list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry for key
+func (c *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+
+	e := *el.Value.(*memoryItem).entry
+	return &e, true
+}
+
+// Set stores the entry for key
+func (c *MemoryCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := *entry
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*memoryItem).entry = &e
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.ll.PushFront(&memoryItem{key: key, entry: &e})
+
+	for c.size > 0 && c.ll.Len() > c.size {
+		el := c.ll.Back()
+		c.ll.Remove(el)
+		delete(c.entries, el.Value.(*memoryItem).key)
+	}
+}
+
+// Delete removes the entry for key
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.ll.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// FileCache is a Cache storing every entry as a file in a directory
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a FileCache in dir, the directory is created if it
+// doesn't exist
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FileCache{dir: dir}, nil
+}
+
+// Get returns the entry for key
+func (c *FileCache) Get(key string) (*CacheEntry, bool) {
+	b, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	entry := new(CacheEntry)
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// Set stores the entry for key, errors writing the file are ignored
+func (c *FileCache) Set(key string, entry *CacheEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// Write to a temporary file first so readers never see half an entry
+	f, err := ioutil.TempFile(c.dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return
+	}
+
+	if err := os.Rename(f.Name(), c.path(key)); err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// Delete removes the entry for key
+func (c *FileCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
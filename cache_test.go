@@ -0,0 +1,173 @@
+package kettle
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCachesResponses(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamappservice/getapplist.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/ISteamApps/GetAppList/v2/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := New(WithHTTPClient(httpClient), WithCache(NewMemoryCache(10)))
+	for i := 0; i < 3; i++ {
+		apps, _, err := client.ISteamAppsService.GetAppList()
+		assert.Nil(t, err)
+		assert.Len(t, apps, 5)
+	}
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestClientCacheRevalidates(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamuserstats/getschemaforgame.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls, notModified := 0, 0
+	mux.HandleFunc("/ISteamUserStats/GetSchemaForGame/v2/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(b)
+	})
+
+	client := New(
+		WithHTTPClient(httpClient),
+		WithCache(NewMemoryCache(10)),
+		WithCacheTTL("GetSchemaForGame", time.Nanosecond),
+	)
+
+	first, _, err := client.ISteamUserStatsService.GetSchemaForGame(98800)
+	assert.Nil(t, err)
+
+	time.Sleep(time.Millisecond)
+	second, _, err := client.ISteamUserStatsService.GetSchemaForGame(98800)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, notModified)
+	assert.Equal(t, first, second)
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	c := NewMemoryCache(2)
+
+	c.Set("a", &CacheEntry{Body: []byte("a")})
+	c.Set("b", &CacheEntry{Body: []byte("b")})
+	c.Get("a")
+	c.Set("c", &CacheEntry{Body: []byte("c")})
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+
+	e, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), e.Body)
+
+	c.Delete("a")
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestFileCache(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "kettle")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := NewFileCache(dir)
+	assert.Nil(t, err)
+
+	expires := time.Now().Add(time.Hour).Round(0)
+	c.Set("key", &CacheEntry{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": []string{"abc"}},
+		Body:       []byte(`{"a":1}`),
+		Expires:    expires,
+	})
+
+	e, ok := c.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusOK, e.StatusCode)
+	assert.Equal(t, "abc", e.Header.Get("ETag"))
+	assert.Equal(t, []byte(`{"a":1}`), e.Body)
+	assert.True(t, expires.Equal(e.Expires))
+
+	c.Delete("key")
+	_, ok = c.Get("key")
+	assert.False(t, ok)
+}
+
+func TestCacheDoerTTL(t *testing.T) {
+	t.Parallel()
+
+	o := &options{cache: NewMemoryCache(1)}
+	assert.Equal(t, 6*time.Hour, cacheTTL(o, "/ISteamApps/GetAppList/v2/"))
+	assert.Equal(t, time.Duration(0), cacheTTL(o, "/IStoreService/GetAppList/v1/"))
+	assert.Equal(t, 24*time.Hour, cacheTTL(o, "/steam/ISteamUserStats/GetSchemaForGame/v2/"))
+	assert.Equal(t, time.Hour, cacheTTL(o, "/api/appdetails"))
+
+	WithCacheTTL("GetAppList", time.Minute)(o)
+	assert.Equal(t, time.Minute, cacheTTL(o, "/ISteamApps/GetAppList/v2/"))
+	assert.Equal(t, time.Minute, cacheTTL(o, "/IStoreService/GetAppList/v1/"))
+
+	WithCacheTTL("IStoreService/GetAppList", 0)(o)
+	assert.Equal(t, time.Duration(0), cacheTTL(o, "/IStoreService/GetAppList/v1/"))
+}
+
+func TestCacheDoerTTLQualifiedWins(t *testing.T) {
+	t.Parallel()
+
+	// Map order is random so check enough times to hit both orders
+	for i := 0; i < 50; i++ {
+		o := &options{cache: NewMemoryCache(1)}
+		WithCacheTTL("ISteamApps/GetAppList", time.Hour)(o)
+		WithCacheTTL("GetAppList", time.Minute)(o)
+		WithCacheTTL("ISteamUserStats/GetSchemaForGame", 2*time.Hour)(o)
+		WithCacheTTL("GetSchemaForGame", time.Second)(o)
+
+		assert.Equal(t, time.Hour, cacheTTL(o, "/ISteamApps/GetAppList/v2/"))
+		assert.Equal(t, time.Minute, cacheTTL(o, "/IStoreService/GetAppList/v1/"))
+		assert.Equal(t, 2*time.Hour, cacheTTL(o, "/ISteamUserStats/GetSchemaForGame/v2/"))
+	}
+}
+
+// cacheTTL is the TTL the cache of o uses for path
+func cacheTTL(o *options, path string) time.Duration {
+	req, _ := http.NewRequest(http.MethodGet, "https://api.steampowered.com"+path, nil)
+	return o.doer(http.DefaultClient).(*cacheDoer).ttl(req)
+}
@@ -0,0 +1,128 @@
+package kettle
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+
+	"github.com/dghubble/sling"
+)
+
+// CommunityService provides a method for accessing the XML endpoints of the
+// Steam community
+type CommunityService struct {
+	sling *sling.Sling
+}
+
+func newCommunityService(sling *sling.Sling) *CommunityService {
+	return &CommunityService{
+		sling: sling.ResponseDecoder(xmlDecoder{}),
+	}
+}
+
+// xmlDecoder decodes XML responses for sling
+type xmlDecoder struct{}
+
+func (d xmlDecoder) Decode(resp *http.Response, v interface{}) error {
+	return xml.NewDecoder(resp.Body).Decode(v)
+}
+
+// GroupMembersParams are the parameters for CommunityService.GroupMembers
+// Page starts at 1, the first page is used if it's 0.
+type GroupMembersParams struct {
+	GroupID SteamID `url:"-"`
+	Page    int     `url:"p,omitempty"`
+	XML     int     `url:"xml"`
+}
+
+type groupMembersResponse struct {
+	GroupMembers
+	Error string `xml:"error"`
+}
+
+// GroupMembers is a page of the members of a group from CommunityService.GroupMembers
+type GroupMembers struct {
+	GroupID        SteamID      `xml:"groupID64"`
+	Details        GroupDetails `xml:"groupDetails"`
+	MemberCount    int          `xml:"memberCount"`
+	TotalPages     int          `xml:"totalPages"`
+	CurrentPage    int          `xml:"currentPage"`
+	StartingMember int          `xml:"startingMember"`
+	Members        []SteamID    `xml:"members>steamID64"`
+}
+
+// GroupDetails describes a group in GroupMembers
+type GroupDetails struct {
+	GroupName     string `xml:"groupName"`
+	GroupURL      string `xml:"groupURL"`
+	Headline      string `xml:"headline"`
+	Summary       string `xml:"summary"`
+	AvatarIcon    string `xml:"avatarIcon"`
+	AvatarMedium  string `xml:"avatarMedium"`
+	AvatarFull    string `xml:"avatarFull"`
+	MemberCount   int    `xml:"memberCount"`
+	MembersInChat int    `xml:"membersInChat"`
+	MembersInGame int    `xml:"membersInGame"`
+	MembersOnline int    `xml:"membersOnline"`
+}
+
+// GroupMembers returns a page of up to 1000 members of a group
+// https://partner.steamgames.com/doc/webapi_overview/xml
+func (s *CommunityService) GroupMembers(params *GroupMembersParams) (*GroupMembers, *http.Response, error) {
+	return s.GroupMembersWithContext(context.Background(), params)
+}
+
+// GroupMembersWithContext is GroupMembers with a context for cancellation
+func (s *CommunityService) GroupMembersWithContext(ctx context.Context, params *GroupMembersParams) (*GroupMembers, *http.Response, error) {
+	response := new(groupMembersResponse)
+
+	p := *params
+	p.XML = 1
+
+	resp, err := receive(ctx, s.sling.New().Get("gid/"+p.GroupID.String()+"/memberslistxml/").QueryStruct(&p), response, nil)
+
+	if err == nil && response.Error != "" {
+		apiErr := newAPIError(resp, 0, response.Error)
+		apiErr.Err = ErrNotFound
+		err = apiErr
+	}
+
+	return &response.GroupMembers, resp, err
+}
+
+// AllGroupMembers returns every member of a group, walking all the pages
+func (s *CommunityService) AllGroupMembers(groupID SteamID) ([]SteamID, error) {
+	return s.AllGroupMembersWithContext(context.Background(), groupID)
+}
+
+// AllGroupMembersWithContext is AllGroupMembers with a context for cancellation
+func (s *CommunityService) AllGroupMembersWithContext(ctx context.Context, groupID SteamID) ([]SteamID, error) {
+	var members []SteamID
+
+	for page := 1; ; page++ {
+		response, _, err := s.GroupMembersWithContext(ctx, &GroupMembersParams{
+			GroupID: groupID,
+			Page:    page,
+		})
+		if err != nil {
+			return members, err
+		}
+
+		members = append(members, response.Members...)
+
+		if page >= response.TotalPages || len(response.Members) == 0 {
+			return members, nil
+		}
+	}
+}
+
+// groupIDFromGID converts the gid of a group from the Web API to a SteamID
+func groupIDFromGID(gid string) (SteamID, error) {
+	n, err := strconv.ParseUint(gid, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return NewSteamID(UniversePublic, AccountTypeClan, 0, uint32(n)), nil
+}
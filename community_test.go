@@ -0,0 +1,53 @@
+package kettle
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommunityServiceGroupMembers(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/steamcommunity/memberslistxml.xml"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/gid/103582791429521412/memberslistxml/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"xml": "1",
+			"p":   "1",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	members, err := client.Community.AllGroupMembers(103582791429521412)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []SteamID{76561197960265740, 76561197960265749, 76561197960287930}, members)
+
+	params := &GroupMembersParams{
+		GroupID: 103582791429521412,
+		Page:    1,
+	}
+	page, _, err := client.Community.GroupMembers(params)
+
+	assert.Nil(t, err)
+	assert.Equal(t, GroupMembersParams{GroupID: 103582791429521412, Page: 1}, *params)
+	assert.Equal(t, SteamID(103582791429521412), page.GroupID)
+	assert.Equal(t, "Valve", page.Details.GroupName)
+	assert.Equal(t, 2, page.Details.MembersOnline)
+	assert.Equal(t, 3, page.MemberCount)
+	assert.Equal(t, 1, page.TotalPages)
+	assert.Len(t, page.Members, 3)
+}
@@ -0,0 +1,88 @@
+package kettle
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unixTime converts a Unix timestamp from Steam, 0 is the zero time.Time
+func unixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(sec, 0).UTC()
+}
+
+// DatePrecision is how exact a parsed release date is
+type DatePrecision int
+
+// The options for DatePrecision
+const (
+	PrecisionUnknown = DatePrecision(0)
+	PrecisionYear    = DatePrecision(1)
+	PrecisionQuarter = DatePrecision(2)
+	PrecisionMonth   = DatePrecision(3)
+	PrecisionDay     = DatePrecision(4)
+)
+
+func (p DatePrecision) String() string {
+	switch p {
+	case PrecisionYear:
+		return "year"
+	case PrecisionQuarter:
+		return "quarter"
+	case PrecisionMonth:
+		return "month"
+	case PrecisionDay:
+		return "day"
+	}
+
+	return "unknown"
+}
+
+// Layouts the store uses for release dates, with the precision they have
+var releaseDateLayouts = []struct {
+	layout    string
+	precision DatePrecision
+}{
+	{"2 Jan, 2006", PrecisionDay},
+	{"Jan 2, 2006", PrecisionDay},
+	{"2 Jan 2006", PrecisionDay},
+	{"2 January, 2006", PrecisionDay},
+	{"January 2, 2006", PrecisionDay},
+	{"2 January 2006", PrecisionDay},
+	{"2006-01-02", PrecisionDay},
+	{"Jan 2006", PrecisionMonth},
+	{"Jan, 2006", PrecisionMonth},
+	{"January 2006", PrecisionMonth},
+	{"January, 2006", PrecisionMonth},
+	{"2006", PrecisionYear},
+}
+
+var quarterRegexp = regexp.MustCompile(`^Q([1-4])\s*,?\s*(\d{4})$`)
+
+// ParseReleaseDate parses a release date from the store such as
+// "12 Jun, 2020", "Oct 20, 2016", "June 2020", "Q3 2024" or "2024". The time
+// is the start of the day, month, quarter or year in UTC. Dates like
+// "Coming soon" and "To be announced" or dates in another language have
+// PrecisionUnknown and a zero time.Time.
+func ParseReleaseDate(s string) (time.Time, DatePrecision) {
+	s = strings.TrimSpace(s)
+
+	if m := quarterRegexp.FindStringSubmatch(strings.ToUpper(s)); m != nil {
+		quarter, _ := strconv.Atoi(m[1])
+		year, _ := strconv.Atoi(m[2])
+		return time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.UTC), PrecisionQuarter
+	}
+
+	for _, l := range releaseDateLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return t, l.precision
+		}
+	}
+
+	return time.Time{}, PrecisionUnknown
+}
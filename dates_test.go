@@ -0,0 +1,45 @@
+package kettle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReleaseDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		date      string
+		time      time.Time
+		precision DatePrecision
+	}{
+		{"12 Jun, 2020", time.Date(2020, time.June, 12, 0, 0, 0, 0, time.UTC), PrecisionDay},
+		{"Oct 20, 2016", time.Date(2016, time.October, 20, 0, 0, 0, 0, time.UTC), PrecisionDay},
+		{"1 September 2021", time.Date(2021, time.September, 1, 0, 0, 0, 0, time.UTC), PrecisionDay},
+		{"June 2020", time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC), PrecisionMonth},
+		{"Sep 2024", time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC), PrecisionMonth},
+		{"Q3 2024", time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC), PrecisionQuarter},
+		{"2025", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), PrecisionYear},
+		{"Coming soon", time.Time{}, PrecisionUnknown},
+		{"To be announced", time.Time{}, PrecisionUnknown},
+		{"", time.Time{}, PrecisionUnknown},
+	}
+
+	for _, test := range tests {
+		parsed, precision := ParseReleaseDate(test.date)
+		assert.True(t, test.time.Equal(parsed), test.date)
+		assert.Equal(t, test.precision, precision, test.date)
+	}
+
+	assert.Equal(t, "quarter", PrecisionQuarter.String())
+}
+
+func TestUnixTimeAccessors(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Date(2017, time.January, 3, 19, 10, 0, 0, time.UTC), NewsItem{Date: 1483470600}.Time())
+	assert.True(t, Player{}.LastLogoffTime().IsZero())
+	assert.Equal(t, int64(1122128079), Player{TimeCreated: 1122128079}.CreatedTime().Unix())
+}
@@ -0,0 +1,84 @@
+package kettle
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors an APIError can wrap, use errors.Is to check for them
+var (
+	ErrInvalidKey     = errors.New("kettle: invalid or missing api key")
+	ErrPrivateProfile = errors.New("kettle: profile is not public")
+	ErrNotFound       = errors.New("kettle: not found")
+	ErrRateLimited    = errors.New("kettle: rate limited")
+	ErrServerError    = errors.New("kettle: steam server error")
+	ErrUnsuccessful   = errors.New("kettle: request was not successful")
+	ErrNoStats        = errors.New("kettle: app has no stats")
+)
+
+// APIError is returned when Steam responds but the request failed, either
+// with a non 2XX status or with a success flag in the body that isn't set.
+type APIError struct {
+	StatusCode int    // HTTP status code of the response
+	Endpoint   string // Path of the request, e.g. /ISteamUser/GetFriendList/v1/
+	Success    int    // Steam success code from the body, 0 if there wasn't one
+	Message    string // Message from Steam or the HTTP status text
+	Err        error  // One of the sentinel errors or nil
+	Attempts   int    // How many times the request was sent
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("kettle: %s failed with status %d", e.Endpoint, e.StatusCode)
+	if e.Success != 0 {
+		msg += fmt.Sprintf(" and success %d", e.Success)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error so errors.Is works with an APIError
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError creates an APIError from a response, the sentinel is picked
+// from the status code.
+func newAPIError(resp *http.Response, success int, message string) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Success:    success,
+		Message:    message,
+		Attempts:   Attempts(resp),
+	}
+
+	if resp.Request != nil {
+		e.Endpoint = resp.Request.URL.Path
+	}
+
+	if e.Message == "" {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		e.Err = ErrInvalidKey
+	case resp.StatusCode == http.StatusNotFound:
+		e.Err = ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		e.Err = ErrRateLimited
+	case resp.StatusCode >= 500:
+		e.Err = ErrServerError
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		e.Err = ErrUnsuccessful
+	}
+
+	return e
+}
+
+// isSuccess checks if the response has a 2XX status code
+func isSuccess(resp *http.Response) bool {
+	return resp.StatusCode >= 200 && resp.StatusCode <= 299
+}
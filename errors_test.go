@@ -0,0 +1,93 @@
+package kettle
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status   int
+		sentinel error
+	}{
+		{http.StatusForbidden, ErrInvalidKey},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusServiceUnavailable, ErrServerError},
+	}
+
+	for _, test := range tests {
+		httpClient, mux, server := testServer()
+
+		status := test.status
+		mux.HandleFunc("/ISteamApps/GetAppList/v2/", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(status)
+			w.Write([]byte("<html></html>"))
+		})
+
+		client := NewClient(httpClient, "")
+		_, resp, err := client.ISteamAppsService.GetAppList()
+		server.Close()
+
+		assert.True(t, errors.Is(err, test.sentinel), "status %d", test.status)
+		assert.Equal(t, test.status, resp.StatusCode)
+
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, test.status, apiErr.StatusCode)
+		assert.Equal(t, "/ISteamApps/GetAppList/v2/", apiErr.Endpoint)
+	}
+}
+
+func TestStoreServiceAppDetailsNotFound(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/store/appdetails.fail.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/api/appdetails", func(w http.ResponseWriter, r *http.Request) {
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	_, _, err := client.Store.AppDetails(1)
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestIPlayerServiceGetOwnedGamesPrivate(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/iplayerservice/ownedgames.private.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/IPlayerService/GetOwnedGames/v1/", func(w http.ResponseWriter, r *http.Request) {
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	games, _, err := client.IPlayerService.GetOwnedGames(&OwnedGamesParams{
+		SteamID: 76561198006575550,
+	})
+
+	assert.True(t, errors.Is(err, ErrPrivateProfile))
+	assert.Len(t, games, 0)
+}
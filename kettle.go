@@ -1,6 +1,7 @@
 package kettle
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/dghubble/sling"
@@ -9,34 +10,100 @@ import (
 // Client is a Steam client for making Steam API requests
 type Client struct {
 	sling *sling.Sling
+	retry *retrier
 
 	Store                  *StoreService
+	Community              *CommunityService
 	IPlayerService         *IPlayerService
 	ISteamAppsService      *ISteamAppsService
 	ISteamNewsService      *ISteamNewsService
 	ISteamUserService      *ISteamUserService
 	ISteamUserStatsService *ISteamUserStatsService
+	IStoreService          *IStoreService
 }
 
 // NewClient returns a new Client
 func NewClient(httpClient *http.Client, key string) *Client {
+	return New(WithHTTPClient(httpClient), WithKey(key))
+}
+
+// NewClientWithLimits returns a new Client where requests to the Web API
+// follow apiLimit and requests to the store follow storeLimit. A request
+// blocks until the limit allows it or its context is done. A nil limit
+// means requests aren't limited.
+func NewClientWithLimits(httpClient *http.Client, key string, apiLimit, storeLimit *RateLimit) *Client {
+	opts := []Option{WithHTTPClient(httpClient), WithKey(key)}
+	if apiLimit != nil {
+		opts = append(opts, WithAPIRateLimit(*apiLimit))
+	}
+	if storeLimit != nil {
+		opts = append(opts, WithStoreRateLimit(*storeLimit))
+	}
+
+	return New(opts...)
+}
+
+// New returns a new Client configured with opts
+func New(opts ...Option) *Client {
+	o := &options{
+		httpClient:       http.DefaultClient,
+		apiBaseURL:       DefaultAPIBaseURL,
+		storeBaseURL:     DefaultStoreBaseURL,
+		communityBaseURL: DefaultCommunityBaseURL,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if o.timeout > 0 {
+		c := *httpClient
+		c.Timeout = o.timeout
+		httpClient = &c
+	}
+
 	b := sling.New().Client(httpClient)
+	if o.userAgent != "" {
+		b.Set("User-Agent", o.userAgent)
+	}
+
+	r := new(retrier)
+	r.set(o.retryPolicy)
 
-	apiBase := b.New().Base("https://api.steampowered.com/")
+	apiBase := b.New().Doer(o.doer(&retryDoer{
+		doer:    limit(httpClient, o.apiLimit),
+		retrier: r,
+	})).Base(o.apiBaseURL)
 	apiBase.QueryStruct(struct {
 		Key string `url:"key"`
 	}{
-		Key: key,
+		Key: o.key,
 	})
 
+	storeBase := b.New().Doer(o.doer(&retryDoer{
+		doer:    limit(httpClient, o.storeLimit),
+		retrier: r,
+	})).Base(o.storeBaseURL)
+
+	communityBase := b.New().Doer(o.doer(&retryDoer{
+		doer:    limit(httpClient, o.communityLimit),
+		retrier: r,
+	})).Base(o.communityBaseURL)
+
 	return &Client{
 		sling:                  b,
-		Store:                  newStoreService(b.New().Base("https://store.steampowered.com/")),
+		retry:                  r,
+		Store:                  newStoreService(storeBase, o.language, o.country),
+		Community:              newCommunityService(communityBase),
 		IPlayerService:         newIPlayerService(apiBase.New()),
 		ISteamAppsService:      newISteamAppsService(apiBase.New()),
 		ISteamNewsService:      newISteamNewsService(apiBase.New()),
 		ISteamUserService:      newISteamUserService(apiBase.New()),
-		ISteamUserStatsService: newISteamUserStatsService(apiBase.New()),
+		ISteamUserStatsService: newISteamUserStatsService(apiBase.New(), o.language),
+		IStoreService:          newIStoreService(apiBase.New()),
 	}
 }
 
@@ -48,3 +115,26 @@ const (
 	False = BoolAsAnInt(0)
 	True  = BoolAsAnInt(1)
 )
+
+// BoolPtr returns a pointer to b, for parameters where False has to be sent
+func BoolPtr(b BoolAsAnInt) *BoolAsAnInt {
+	return &b
+}
+
+// receive sends the request built by s with ctx attached. Success responses
+// are decoded into successV and other responses into failureV. A non 2XX
+// response is returned as an *APIError.
+func receive(ctx context.Context, s *sling.Sling, successV, failureV interface{}) (*http.Response, error) {
+	req, err := s.Request()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Do(req.WithContext(ctx), successV, failureV)
+	if resp != nil && !isSuccess(resp) {
+		// The body of a failure is often html so a decoding error is dropped
+		return resp, newAPIError(resp, 0, "")
+	}
+
+	return resp, err
+}
@@ -0,0 +1,117 @@
+package kettle
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dghubble/sling"
+)
+
+// RateLimit is a token bucket limit of Requests every Per. Burst is how many
+// requests can be made at once, if it's 0 it is the same as Requests.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+// Known limits Steam enforces
+var (
+	// APIRateLimit is the daily limit for a key on api.steampowered.com
+	APIRateLimit = RateLimit{Requests: 100000, Per: 24 * time.Hour}
+	// StoreRateLimit is the limit for appdetails on store.steampowered.com
+	StoreRateLimit = RateLimit{Requests: 200, Per: 5 * time.Minute}
+)
+
+// limiter is a token bucket shared by all the requests to one host
+type limiter struct {
+	mu       sync.Mutex
+	tokens   float64
+	burst    float64
+	interval time.Duration // Time it takes to add one token
+	last     time.Time
+}
+
+func newLimiter(l RateLimit) *limiter {
+	burst := l.Burst
+	if burst <= 0 {
+		burst = l.Requests
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	interval := time.Duration(0)
+	if l.Requests > 0 {
+		interval = l.Per / time.Duration(l.Requests)
+	}
+
+	return &limiter{
+		tokens:   float64(burst),
+		burst:    float64(burst),
+		interval: interval,
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done
+func (l *limiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		if l.interval > 0 {
+			l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		} else {
+			l.tokens = l.burst
+		}
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - l.tokens) * float64(l.interval))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// limitedDoer waits on the limiter before sending a request
+type limitedDoer struct {
+	doer    sling.Doer
+	limiter *limiter
+}
+
+func (d *limitedDoer) Do(req *http.Request) (*http.Response, error) {
+	if err := d.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return d.doer.Do(req)
+}
+
+// limit wraps doer so it follows l, a nil l means no limit
+func limit(doer sling.Doer, l *RateLimit) sling.Doer {
+	if l == nil {
+		return doer
+	}
+
+	return &limitedDoer{
+		doer:    doer,
+		limiter: newLimiter(*l),
+	}
+}
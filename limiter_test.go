@@ -0,0 +1,60 @@
+package kettle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientWithLimitsBlocks(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/ISteamApps/GetAppList/v2/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"applist":{"apps":[]}}`))
+	})
+
+	client := NewClientWithLimits(httpClient, "", &RateLimit{Requests: 1, Per: 50 * time.Millisecond}, nil)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, _, err := client.ISteamAppsService.GetAppList()
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, 3, calls)
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+}
+
+func TestNewClientWithLimitsHonorsContext(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/api/appdetails", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"1":{"success":true,"data":{}}}`))
+	})
+
+	client := NewClientWithLimits(httpClient, "", nil, &RateLimit{Requests: 1, Per: time.Hour})
+
+	_, _, err := client.Store.AppDetails(1)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, _, err = client.Store.AppDetailsWithContext(ctx, 1)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 1, calls)
+}
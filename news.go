@@ -1,7 +1,9 @@
 package kettle
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/dghubble/sling"
 )
@@ -48,13 +50,23 @@ type NewsItem struct {
 	FeedName    string `json:"feedname"`
 }
 
+// Time is Date as a time.Time
+func (n NewsItem) Time() time.Time {
+	return unixTime(n.Date)
+}
+
 // GetNewsForApp returns the latest of a game specified by its appID.
 // https://developer.valvesoftware.com/wiki/Steam_Web_API#GetNewsForApp_.28v0002.29
 // https://wiki.teamfortress.com/wiki/WebAPI/GetNewsForApp
 func (s *ISteamNewsService) GetNewsForApp(params *GetNewsForAppParams) ([]NewsItem, *http.Response, error) {
+	return s.GetNewsForAppWithContext(context.Background(), params)
+}
+
+// GetNewsForAppWithContext is GetNewsForApp with a context for cancellation
+func (s *ISteamNewsService) GetNewsForAppWithContext(ctx context.Context, params *GetNewsForAppParams) ([]NewsItem, *http.Response, error) {
 	response := new(newsResponse)
 
-	resp, err := s.sling.New().Get("GetNewsForApp/v2/").QueryStruct(params).ReceiveSuccess(response)
+	resp, err := receive(ctx, s.sling.New().Get("GetNewsForApp/v2/").QueryStruct(params), response, nil)
 
 	return response.AppNews.NewsItems, resp, err
 }
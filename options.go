@@ -0,0 +1,141 @@
+package kettle
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Default base URLs for the Steam endpoints
+const (
+	DefaultAPIBaseURL       = "https://api.steampowered.com/"
+	DefaultStoreBaseURL     = "https://store.steampowered.com/"
+	DefaultCommunityBaseURL = "https://steamcommunity.com/"
+)
+
+// Option configures a Client created with New
+type Option func(*options)
+
+type options struct {
+	httpClient       *http.Client
+	key              string
+	apiBaseURL       string
+	storeBaseURL     string
+	communityBaseURL string
+	userAgent        string
+	language         string
+	country          string
+	timeout          time.Duration
+	apiLimit         *RateLimit
+	storeLimit       *RateLimit
+	communityLimit   *RateLimit
+	retryPolicy      *RetryPolicy
+	cache            Cache
+	cacheTTLs        map[string]time.Duration
+}
+
+// WithHTTPClient sets the http.Client used for requests, the default is
+// http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithKey sets the Steam Web API key
+func WithKey(key string) Option {
+	return func(o *options) {
+		o.key = key
+	}
+}
+
+// WithAPIBaseURL sets the base URL for the Web API, useful for proxies and
+// tests
+func WithAPIBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.apiBaseURL = withTrailingSlash(baseURL)
+	}
+}
+
+// WithStoreBaseURL sets the base URL for the store, useful for proxies and
+// tests
+func WithStoreBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.storeBaseURL = withTrailingSlash(baseURL)
+	}
+}
+
+// WithCommunityBaseURL sets the base URL for the Steam community, useful for
+// proxies and tests
+func WithCommunityBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.communityBaseURL = withTrailingSlash(baseURL)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithLanguage sets the default language, e.g. "english" or "german", for
+// requests that support one
+func WithLanguage(language string) Option {
+	return func(o *options) {
+		o.language = language
+	}
+}
+
+// WithCountry sets the default two letter country code, e.g. "US", for store
+// requests
+func WithCountry(country string) Option {
+	return func(o *options) {
+		o.country = country
+	}
+}
+
+// WithTimeout sets a timeout for every request. The http.Client passed to
+// WithHTTPClient is copied and not changed.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithAPIRateLimit limits requests to the Web API
+func WithAPIRateLimit(l RateLimit) Option {
+	return func(o *options) {
+		o.apiLimit = &l
+	}
+}
+
+// WithStoreRateLimit limits requests to the store
+func WithStoreRateLimit(l RateLimit) Option {
+	return func(o *options) {
+		o.storeLimit = &l
+	}
+}
+
+// WithCommunityRateLimit limits requests to the Steam community
+func WithCommunityRateLimit(l RateLimit) Option {
+	return func(o *options) {
+		o.communityLimit = &l
+	}
+}
+
+// WithRetryPolicy turns on retries, see Client.SetRetryPolicy
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = &p
+	}
+}
+
+func withTrailingSlash(u string) string {
+	if strings.HasSuffix(u, "/") {
+		return u
+	}
+
+	return u + "/"
+}
@@ -0,0 +1,60 @@
+package kettle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/store/appdetails.json"
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/steam/store/api/appdetails", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"appids": "289070",
+			"l":      "german",
+			"cc":     "DE",
+		}, r)
+		assert.Equal(t, "kettle-test", r.Header.Get("User-Agent"))
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	mux.HandleFunc("/steam/api/ISteamApps/GetAppList/v2/", func(w http.ResponseWriter, r *http.Request) {
+		assertQuery(t, map[string]string{"key": "abc"}, r)
+		assert.Equal(t, "kettle-test", r.Header.Get("User-Agent"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"applist":{"apps":[]}}`))
+	})
+
+	client := New(
+		WithKey("abc"),
+		WithAPIBaseURL(server.URL+"/steam/api"),
+		WithStoreBaseURL(server.URL+"/steam/store/"),
+		WithUserAgent("kettle-test"),
+		WithLanguage("german"),
+		WithCountry("DE"),
+	)
+
+	game, _, err := client.Store.AppDetails(289070)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(289070), game.SteamAppID)
+
+	_, _, err = client.ISteamAppsService.GetAppList()
+	assert.Nil(t, err)
+}
@@ -0,0 +1,146 @@
+package kettle
+
+import "strings"
+
+// PersonaState is the online status of a Player
+type PersonaState int
+
+// The options for PersonaState
+const (
+	Offline        = PersonaState(0)
+	Online         = PersonaState(1)
+	Busy           = PersonaState(2)
+	Away           = PersonaState(3)
+	Snooze         = PersonaState(4)
+	LookingToTrade = PersonaState(5)
+	LookingToPlay  = PersonaState(6)
+)
+
+func (s PersonaState) String() string {
+	switch s {
+	case Offline:
+		return "offline"
+	case Online:
+		return "online"
+	case Busy:
+		return "busy"
+	case Away:
+		return "away"
+	case Snooze:
+		return "snooze"
+	case LookingToTrade:
+		return "looking to trade"
+	case LookingToPlay:
+		return "looking to play"
+	}
+
+	return "unknown"
+}
+
+// CommunityVisibility is who can see the profile of a Player
+type CommunityVisibility int
+
+// The options for CommunityVisibility
+const (
+	Private     = CommunityVisibility(1)
+	FriendsOnly = CommunityVisibility(2)
+	Public      = CommunityVisibility(3)
+)
+
+func (v CommunityVisibility) String() string {
+	switch v {
+	case Private:
+		return "private"
+	case FriendsOnly:
+		return "friends only"
+	case Public:
+		return "public"
+	}
+
+	return "unknown"
+}
+
+// ProfileState is if a Player has set up their community profile
+type ProfileState int
+
+// The options for ProfileState
+const (
+	ProfileNotConfigured = ProfileState(0)
+	ProfileConfigured    = ProfileState(1)
+)
+
+func (s ProfileState) String() string {
+	if s == ProfileConfigured {
+		return "configured"
+	}
+
+	return "not configured"
+}
+
+// PersonaStateFlags are extra details about the status of a Player
+type PersonaStateFlags int
+
+// The bits of PersonaStateFlags
+const (
+	FlagHasRichPresence      = PersonaStateFlags(1)
+	FlagInJoinableGame       = PersonaStateFlags(2)
+	FlagGolden               = PersonaStateFlags(4)
+	FlagRemotePlayTogether   = PersonaStateFlags(8)
+	FlagClientTypeWeb        = PersonaStateFlags(256)
+	FlagClientTypeMobile     = PersonaStateFlags(512)
+	FlagClientTypeTenfoot    = PersonaStateFlags(1024)
+	FlagClientTypeVR         = PersonaStateFlags(2048)
+	FlagLaunchTypeGamepad    = PersonaStateFlags(4096)
+	FlagLaunchTypeCompatTool = PersonaStateFlags(8192)
+)
+
+var personaStateFlagNames = []struct {
+	flag PersonaStateFlags
+	name string
+}{
+	{FlagHasRichPresence, "HasRichPresence"},
+	{FlagInJoinableGame, "InJoinableGame"},
+	{FlagGolden, "Golden"},
+	{FlagRemotePlayTogether, "RemotePlayTogether"},
+	{FlagClientTypeWeb, "ClientTypeWeb"},
+	{FlagClientTypeMobile, "ClientTypeMobile"},
+	{FlagClientTypeTenfoot, "ClientTypeTenfoot"},
+	{FlagClientTypeVR, "ClientTypeVR"},
+	{FlagLaunchTypeGamepad, "LaunchTypeGamepad"},
+	{FlagLaunchTypeCompatTool, "LaunchTypeCompatTool"},
+}
+
+// Has checks if all the bits of flag are set
+func (f PersonaStateFlags) Has(flag PersonaStateFlags) bool {
+	return f&flag == flag
+}
+
+func (f PersonaStateFlags) String() string {
+	var names []string
+	for _, n := range personaStateFlagNames {
+		if f.Has(n.flag) {
+			names = append(names, n.name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "none"
+	}
+
+	return strings.Join(names, "|")
+}
+
+// IsPublic checks if anyone can see the profile
+func (p Player) IsPublic() bool {
+	return p.CommunityVisibility == Public
+}
+
+// IsOnline checks if the player isn't offline
+func (p Player) IsOnline() bool {
+	return p.PersonaState != Offline
+}
+
+// InGame checks if the player is playing a game right now
+func (p Player) InGame() bool {
+	return p.GameID != ""
+}
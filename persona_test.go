@@ -0,0 +1,42 @@
+package kettle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonaStrings(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "looking to trade", LookingToTrade.String())
+	assert.Equal(t, "unknown", PersonaState(42).String())
+	assert.Equal(t, "friends only", FriendsOnly.String())
+	assert.Equal(t, "configured", ProfileConfigured.String())
+	assert.Equal(t, "none", PersonaStateFlags(0).String())
+	assert.Equal(t, "HasRichPresence|ClientTypeWeb", (FlagHasRichPresence | FlagClientTypeWeb).String())
+}
+
+func TestPersonaStateFlagsHas(t *testing.T) {
+	t.Parallel()
+
+	flags := FlagInJoinableGame | FlagClientTypeMobile
+	assert.True(t, flags.Has(FlagInJoinableGame))
+	assert.True(t, flags.Has(FlagInJoinableGame|FlagClientTypeMobile))
+	assert.False(t, flags.Has(FlagGolden))
+	assert.False(t, flags.Has(FlagInJoinableGame|FlagGolden))
+}
+
+func TestPlayerPredicates(t *testing.T) {
+	t.Parallel()
+
+	p := Player{CommunityVisibility: Public, PersonaState: Away, GameID: "236870"}
+	assert.True(t, p.IsPublic())
+	assert.True(t, p.IsOnline())
+	assert.True(t, p.InGame())
+
+	p = Player{CommunityVisibility: Private}
+	assert.False(t, p.IsPublic())
+	assert.False(t, p.IsOnline())
+	assert.False(t, p.InGame())
+}
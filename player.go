@@ -1,7 +1,12 @@
 package kettle
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/dghubble/sling"
 )
@@ -22,27 +27,65 @@ type ownedGameResponse struct {
 }
 
 type ogresp struct {
-	GameCount int        `json:"game_count"`
+	GameCount *int       `json:"game_count"` // Missing when the profile isn't public
 	Games     []UserGame `json:"games"`
 }
 
 // UserGame is a game listing specific to a user for IPlayerService.GetOwnedGames
+// The fields after VisibleStats are only set with IncludeExtendedAppInfo.
 type UserGame struct {
-	AppID           int64  `json:"appid"`
-	Name            string `json:"name,omitempty"`
-	Playtime2Weeks  int    `json:"playtime_2weeks,omitempty"`
-	PlaytimeForever int    `json:"playtime_forever"`
-	ImgIconURL      string `json:"img_icon_url,omitempty"`
-	ImgLogoURL      string `json:"img_logo_url,omitempty"`
-	VisibleStats    bool   `json:"has_community_visible_stats,omitempty"`
+	AppID                  int64  `json:"appid"`
+	Name                   string `json:"name,omitempty"`
+	Playtime2Weeks         int    `json:"playtime_2weeks,omitempty"`
+	PlaytimeForever        int    `json:"playtime_forever"`
+	PlaytimeWindowsForever int    `json:"playtime_windows_forever,omitempty"`
+	PlaytimeMacForever     int    `json:"playtime_mac_forever,omitempty"`
+	PlaytimeLinuxForever   int    `json:"playtime_linux_forever,omitempty"`
+	PlaytimeDeckForever    int    `json:"playtime_deck_forever,omitempty"`
+	PlaytimeDisconnected   int    `json:"playtime_disconnected,omitempty"`
+	RTimeLastPlayed        int64  `json:"rtime_last_played,omitempty"`
+	ImgIconURL             string `json:"img_icon_url,omitempty"`
+	ImgLogoURL             string `json:"img_logo_url,omitempty"`
+	VisibleStats           bool   `json:"has_community_visible_stats,omitempty"`
+	CapsuleFilename        string `json:"capsule_filename,omitempty"`
+	SortAs                 string `json:"sort_as,omitempty"`
+	HasWorkshop            bool   `json:"has_workshop,omitempty"`
+	HasMarket              bool   `json:"has_market,omitempty"`
+	HasDLC                 bool   `json:"has_dlc,omitempty"`
+	HasLeaderboards        bool   `json:"has_leaderboards,omitempty"`
+	ContentDescriptorIDs   []int  `json:"content_descriptorids,omitempty"`
+}
+
+// LastPlayedTime is RTimeLastPlayed as a time.Time
+func (g UserGame) LastPlayedTime() time.Time {
+	return unixTime(g.RTimeLastPlayed)
 }
 
 // OwnedGamesParams are the parameters for IPlayerService.GetOwnedGames
+// Steam skips unvetted apps by default, set SkipUnvettedApps to
+// BoolPtr(False) to include them.
 type OwnedGamesParams struct {
-	SteamID        string      `url:"steamid"`
-	IncludeAppInfo BoolAsAnInt `url:"include_appinfo,omitempty"`
-	IncludeFree    BoolAsAnInt `url:"include_played_free_games,omitempty"`
-	//AppIdFilter too complicated right now
+	SteamID                SteamID      `url:"steamid"`
+	IncludeAppInfo         BoolAsAnInt  `url:"include_appinfo,omitempty"`
+	IncludeFree            BoolAsAnInt  `url:"include_played_free_games,omitempty"`
+	IncludeFreeSub         BoolAsAnInt  `url:"include_free_sub,omitempty"`
+	SkipUnvettedApps       *BoolAsAnInt `url:"skip_unvetted_apps,omitempty"`
+	IncludeExtendedAppInfo BoolAsAnInt  `url:"include_extended_appinfo,omitempty"`
+	Language               string       `url:"language,omitempty"`
+	AppIDsFilter           AppIDFilter  `url:"appids_filter,omitempty"`
+}
+
+// AppIDFilter limits IPlayerService.GetOwnedGames to these apps, only the
+// ones the user owns are returned
+type AppIDFilter []int64
+
+// EncodeValues encodes the filter as appids_filter[0], appids_filter[1], ...
+func (f AppIDFilter) EncodeValues(key string, v *url.Values) error {
+	for i, id := range f {
+		v.Set(key+"["+strconv.Itoa(i)+"]", strconv.FormatInt(id, 10))
+	}
+
+	return nil
 }
 
 type playedGameResponse struct {
@@ -50,7 +93,7 @@ type playedGameResponse struct {
 }
 
 type pgresp struct {
-	TotalCount int          `json:"total_count"`
+	TotalCount *int         `json:"total_count"` // Missing when the profile isn't public
 	Games      []PlayedGame `json:"games"`
 }
 
@@ -70,17 +113,28 @@ type PlayedGame struct {
 // https://developer.valvesoftware.com/wiki/Steam_Web_API#GetOwnedGames_.28v0001.29
 // https://lab.xpaw.me/steam_api_documentation.html#IPlayerService_GetOwnedGames_v1
 func (s IPlayerService) GetOwnedGames(params *OwnedGamesParams) ([]UserGame, *http.Response, error) {
+	return s.GetOwnedGamesWithContext(context.Background(), params)
+}
+
+// GetOwnedGamesWithContext is GetOwnedGames with a context for cancellation
+func (s IPlayerService) GetOwnedGamesWithContext(ctx context.Context, params *OwnedGamesParams) ([]UserGame, *http.Response, error) {
 	response := new(ownedGameResponse)
 
-	resp, err := s.sling.New().Get("GetOwnedGames/v1/").QueryStruct(params).Receive(response, response)
+	resp, err := receive(ctx, s.sling.New().Get("GetOwnedGames/v1/").QueryStruct(params), response, response)
+
+	if err == nil && response.OwnedGameResponse.GameCount == nil {
+		apiErr := newAPIError(resp, 0, "owned games are not public")
+		apiErr.Err = ErrPrivateProfile
+		err = apiErr
+	}
 
 	return response.OwnedGameResponse.Games, resp, err
 }
 
 // RecentGamesParams are the parameters for IPlayerService.GetRecentlyPlayedGames
 type RecentGamesParams struct {
-	SteamID string `url:"steamid"`
-	Count   int    `url:"count,omitempty"`
+	SteamID SteamID `url:"steamid"`
+	Count   int     `url:"count,omitempty"`
 }
 
 // GetRecentlyPlayedGames returns a list of games a player has played in the last two weeks, if the profile is publicly visible.
@@ -88,9 +142,177 @@ type RecentGamesParams struct {
 // (ie the WebAPI key you are using is linked to the steamid you are requesting).
 // https://developer.valvesoftware.com/wiki/Steam_Web_API#GetRecentlyPlayedGames_.28v0001.29
 func (s IPlayerService) GetRecentlyPlayedGames(params *RecentGamesParams) ([]PlayedGame, *http.Response, error) {
+	return s.GetRecentlyPlayedGamesWithContext(context.Background(), params)
+}
+
+// GetRecentlyPlayedGamesWithContext is GetRecentlyPlayedGames with a context for cancellation
+func (s IPlayerService) GetRecentlyPlayedGamesWithContext(ctx context.Context, params *RecentGamesParams) ([]PlayedGame, *http.Response, error) {
 	response := new(playedGameResponse)
 
-	resp, err := s.sling.New().Get("GetRecentlyPlayedGames/v0001/").QueryStruct(params).Receive(response, response)
+	resp, err := receive(ctx, s.sling.New().Get("GetRecentlyPlayedGames/v0001/").QueryStruct(params), response, response)
+
+	if err == nil && response.PlayedGameResponse.TotalCount == nil {
+		apiErr := newAPIError(resp, 0, "recently played games are not public")
+		apiErr.Err = ErrPrivateProfile
+		err = apiErr
+	}
 
 	return response.PlayedGameResponse.Games, resp, err
 }
+
+type steamLevelResponse struct {
+	Response struct {
+		PlayerLevel *int `json:"player_level"` // Missing when the profile isn't public
+	} `json:"response"`
+}
+
+// GetSteamLevel returns the Steam level of a user
+// https://lab.xpaw.me/steam_api_documentation.html#IPlayerService_GetSteamLevel_v1
+func (s IPlayerService) GetSteamLevel(steamID SteamID) (int, *http.Response, error) {
+	return s.GetSteamLevelWithContext(context.Background(), steamID)
+}
+
+// GetSteamLevelWithContext is GetSteamLevel with a context for cancellation
+func (s IPlayerService) GetSteamLevelWithContext(ctx context.Context, steamID SteamID) (int, *http.Response, error) {
+	response := new(steamLevelResponse)
+
+	type params struct {
+		SteamID SteamID `url:"steamid"`
+	}
+
+	p := &params{
+		SteamID: steamID,
+	}
+
+	resp, err := receive(ctx, s.sling.New().Get("GetSteamLevel/v1/").QueryStruct(p), response, nil)
+
+	if err != nil {
+		return 0, resp, err
+	}
+
+	if response.Response.PlayerLevel == nil {
+		apiErr := newAPIError(resp, 0, "steam level is not public")
+		apiErr.Err = ErrPrivateProfile
+		return 0, resp, apiErr
+	}
+
+	return *response.Response.PlayerLevel, resp, nil
+}
+
+type badgesResponse struct {
+	Response json.RawMessage `json:"response"`
+}
+
+// emptyResponse checks if Steam sent an empty response object, which it
+// does for private profiles
+func emptyResponse(raw json.RawMessage) bool {
+	var fields map[string]json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &fields) != nil {
+		return true
+	}
+
+	return len(fields) == 0
+}
+
+// Badges are the badges and experience of a user from IPlayerService.GetBadges
+type Badges struct {
+	Badges                     []Badge `json:"badges"`
+	PlayerXP                   int     `json:"player_xp"`
+	PlayerLevel                int     `json:"player_level"`
+	PlayerXPNeededToLevelUp    int     `json:"player_xp_needed_to_level_up"`
+	PlayerXPNeededCurrentLevel int     `json:"player_xp_needed_current_level"`
+}
+
+// Badge is a badge a user has, AppID is set for game badges
+type Badge struct {
+	BadgeID         int    `json:"badgeid"`
+	AppID           int64  `json:"appid,omitempty"`
+	Level           int    `json:"level"`
+	CompletionTime  int64  `json:"completion_time"`
+	XP              int    `json:"xp"`
+	CommunityItemID string `json:"communityitemid,omitempty"`
+	BorderColor     int    `json:"border_color,omitempty"`
+	Scarcity        int    `json:"scarcity"`
+}
+
+// CompletedTime is CompletionTime as a time.Time
+func (b Badge) CompletedTime() time.Time {
+	return unixTime(b.CompletionTime)
+}
+
+// GetBadges returns the badges a user has along with their experience
+// https://lab.xpaw.me/steam_api_documentation.html#IPlayerService_GetBadges_v1
+func (s IPlayerService) GetBadges(steamID SteamID) (*Badges, *http.Response, error) {
+	return s.GetBadgesWithContext(context.Background(), steamID)
+}
+
+// GetBadgesWithContext is GetBadges with a context for cancellation
+func (s IPlayerService) GetBadgesWithContext(ctx context.Context, steamID SteamID) (*Badges, *http.Response, error) {
+	response := new(badgesResponse)
+
+	type params struct {
+		SteamID SteamID `url:"steamid"`
+	}
+
+	p := &params{
+		SteamID: steamID,
+	}
+
+	resp, err := receive(ctx, s.sling.New().Get("GetBadges/v1/").QueryStruct(p), response, nil)
+
+	badges := new(Badges)
+	if err == nil && emptyResponse(response.Response) {
+		apiErr := newAPIError(resp, 0, "badges are not public")
+		apiErr.Err = ErrPrivateProfile
+		err = apiErr
+	}
+	if err == nil {
+		err = json.Unmarshal(response.Response, badges)
+	}
+
+	return badges, resp, err
+}
+
+// CommunityBadgeProgressParams are the parameters for IPlayerService.GetCommunityBadgeProgress
+// BadgeID is the community badge, 2 (Pillar of Community) if 0
+type CommunityBadgeProgressParams struct {
+	SteamID SteamID `url:"steamid"`
+	BadgeID int     `url:"badgeid,omitempty"`
+}
+
+type badgeProgressResponse struct {
+	Response json.RawMessage `json:"response"`
+}
+
+// Quest is a task for a community badge from IPlayerService.GetCommunityBadgeProgress
+type Quest struct {
+	QuestID   int  `json:"questid"`
+	Completed bool `json:"completed"`
+}
+
+// GetCommunityBadgeProgress returns the quests of a community badge and if the user completed them
+// https://lab.xpaw.me/steam_api_documentation.html#IPlayerService_GetCommunityBadgeProgress_v1
+func (s IPlayerService) GetCommunityBadgeProgress(params *CommunityBadgeProgressParams) ([]Quest, *http.Response, error) {
+	return s.GetCommunityBadgeProgressWithContext(context.Background(), params)
+}
+
+// GetCommunityBadgeProgressWithContext is GetCommunityBadgeProgress with a context for cancellation
+func (s IPlayerService) GetCommunityBadgeProgressWithContext(ctx context.Context, params *CommunityBadgeProgressParams) ([]Quest, *http.Response, error) {
+	response := new(badgeProgressResponse)
+
+	resp, err := receive(ctx, s.sling.New().Get("GetCommunityBadgeProgress/v1/").QueryStruct(params), response, nil)
+
+	var progress struct {
+		Quests []Quest `json:"quests"`
+	}
+	if err == nil && emptyResponse(response.Response) {
+		apiErr := newAPIError(resp, 0, "badge progress is not public")
+		apiErr.Err = ErrPrivateProfile
+		err = apiErr
+	}
+	if err == nil {
+		err = json.Unmarshal(response.Response, &progress)
+	}
+
+	return progress.Quests, resp, err
+}
@@ -1,6 +1,7 @@
 package kettle
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
@@ -33,7 +34,7 @@ func TestIPlayerServiceGetOwnedGames(t *testing.T) {
 
 	client := NewClient(httpClient, "")
 	games, _, err := client.IPlayerService.GetOwnedGames(&OwnedGamesParams{
-		SteamID:        "76561198006575550",
+		SteamID:        76561198006575550,
 		IncludeAppInfo: True,
 	})
 
@@ -74,7 +75,7 @@ func TestIplayerServiceGetRecentlyPlayed(t *testing.T) {
 
 	client := NewClient(httpClient, "")
 	games, _, err := client.IPlayerService.GetRecentlyPlayedGames(&RecentGamesParams{
-		SteamID: "76561198006575550",
+		SteamID: 76561198006575550,
 	})
 
 	assert.Nil(t, err)
@@ -87,3 +88,247 @@ func TestIplayerServiceGetRecentlyPlayed(t *testing.T) {
 	assert.Equal(t, "8f48f2265746c08cd1fd7b8ce5f310172eb4fa12", games[0].ImgIconURL)
 	assert.Equal(t, "ba0d065302833a4851093410ced0e1c82df30ba4", games[0].ImgLogoURL)
 }
+
+func TestIPlayerServiceGetSteamLevel(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/iplayerservice/getsteamlevel.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/IPlayerService/GetSteamLevel/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"steamid": "76561198006575550",
+			"key":     "",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	level, _, err := client.IPlayerService.GetSteamLevel(76561198006575550)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 42, level)
+}
+
+func TestIPlayerServiceGetBadges(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/iplayerservice/getbadges.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/IPlayerService/GetBadges/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"steamid": "76561198006575550",
+			"key":     "",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	badges, _, err := client.IPlayerService.GetBadges(76561198006575550)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4356, badges.PlayerXP)
+	assert.Equal(t, 42, badges.PlayerLevel)
+	assert.Equal(t, 44, badges.PlayerXPNeededToLevelUp)
+	assert.Equal(t, 4300, badges.PlayerXPNeededCurrentLevel)
+
+	assert.Len(t, badges.Badges, 2)
+	assert.Equal(t, 13, badges.Badges[0].BadgeID)
+	assert.Equal(t, 514, badges.Badges[0].Level)
+	assert.Equal(t, 764, badges.Badges[0].XP)
+	assert.Equal(t, 2370427, badges.Badges[0].Scarcity)
+	assert.Equal(t, int64(1483470600), badges.Badges[0].CompletedTime().Unix())
+
+	assert.Equal(t, int64(440), badges.Badges[1].AppID)
+	assert.Equal(t, "1203829441", badges.Badges[1].CommunityItemID)
+}
+
+func TestIPlayerServiceGetBadgesEmptyAndPrivate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filePath string
+		private  bool
+	}{
+		{"./json/iplayerservice/getbadges.empty.json", false},
+		{"./json/iplayerservice/getbadges.private.json", true},
+	}
+
+	for _, test := range tests {
+		httpClient, mux, server := testServer()
+
+		filePath := test.filePath
+		mux.HandleFunc("/IPlayerService/GetBadges/v1/", func(w http.ResponseWriter, r *http.Request) {
+			b, err := getTestFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to open testfile %s", filePath)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(b)
+		})
+
+		client := NewClient(httpClient, "")
+		badges, _, err := client.IPlayerService.GetBadges(76561198006575550)
+		server.Close()
+
+		if test.private {
+			assert.True(t, errors.Is(err, ErrPrivateProfile), filePath)
+			continue
+		}
+		assert.Nil(t, err, filePath)
+		assert.Len(t, badges.Badges, 0)
+		assert.Equal(t, 100, badges.PlayerXPNeededToLevelUp)
+	}
+}
+
+func TestIPlayerServiceGetCommunityBadgeProgress(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/iplayerservice/getcommunitybadgeprogress.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/IPlayerService/GetCommunityBadgeProgress/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"steamid": "76561198006575550",
+			"badgeid": "2",
+			"key":     "",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	quests, _, err := client.IPlayerService.GetCommunityBadgeProgress(&CommunityBadgeProgressParams{
+		SteamID: 76561198006575550,
+		BadgeID: 2,
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, quests, 3)
+	assert.Equal(t, 115, quests[0].QuestID)
+	assert.Equal(t, true, quests[0].Completed)
+	assert.Equal(t, false, quests[1].Completed)
+}
+
+func TestIPlayerServiceGetOwnedGamesFiltered(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/iplayerservice/ownedgames.extended.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/IPlayerService/GetOwnedGames/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"steamid":                  "76561198006575550",
+			"include_appinfo":          "1",
+			"include_free_sub":         "1",
+			"skip_unvetted_apps":       "0",
+			"include_extended_appinfo": "1",
+			"language":                 "english",
+			"appids_filter[0]":         "440",
+			"appids_filter[1]":         "570",
+			"key":                      "",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	games, _, err := client.IPlayerService.GetOwnedGames(&OwnedGamesParams{
+		SteamID:                76561198006575550,
+		IncludeAppInfo:         True,
+		IncludeFreeSub:         True,
+		SkipUnvettedApps:       BoolPtr(False),
+		IncludeExtendedAppInfo: True,
+		Language:               "english",
+		AppIDsFilter:           AppIDFilter{440, 570},
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, games, 1)
+
+	assert.Equal(t, int64(440), games[0].AppID)
+	assert.Equal(t, 6000, games[0].PlaytimeWindowsForever)
+	assert.Equal(t, 129, games[0].PlaytimeLinuxForever)
+	assert.Equal(t, int64(1677881282), games[0].LastPlayedTime().Unix())
+	assert.Equal(t, "capsule_184x69.jpg", games[0].CapsuleFilename)
+	assert.Equal(t, true, games[0].HasWorkshop)
+	assert.Equal(t, true, games[0].HasMarket)
+	assert.Equal(t, true, games[0].HasDLC)
+	assert.Equal(t, []int{2, 5}, games[0].ContentDescriptorIDs)
+}
+
+func TestIPlayerServiceGetCommunityBadgeProgressEmptyAndPrivate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filePath string
+		private  bool
+	}{
+		{"./json/iplayerservice/getcommunitybadgeprogress.empty.json", false},
+		{"./json/iplayerservice/getbadges.private.json", true},
+	}
+
+	for _, test := range tests {
+		httpClient, mux, server := testServer()
+
+		filePath := test.filePath
+		mux.HandleFunc("/IPlayerService/GetCommunityBadgeProgress/v1/", func(w http.ResponseWriter, r *http.Request) {
+			b, err := getTestFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to open testfile %s", filePath)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(b)
+		})
+
+		client := NewClient(httpClient, "")
+		quests, _, err := client.IPlayerService.GetCommunityBadgeProgress(&CommunityBadgeProgressParams{
+			SteamID: 76561198006575550,
+		})
+		server.Close()
+
+		if test.private {
+			assert.True(t, errors.Is(err, ErrPrivateProfile), filePath)
+			continue
+		}
+		assert.Nil(t, err, filePath)
+		assert.Len(t, quests, 0)
+	}
+}
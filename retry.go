@@ -0,0 +1,177 @@
+package kettle
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dghubble/sling"
+)
+
+// RetryPolicy decides how failed GET requests are retried. A request is
+// retried when Steam responds with 429 or a 5XX status or when it couldn't be
+// sent at all.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first, 1 or less means no retries
+	BaseBackoff time.Duration // Wait before the first retry, doubled for every retry after
+	MaxBackoff  time.Duration // Longest wait between attempts, 0 means no cap. A Retry-After longer than this isn't waited for.
+}
+
+// DefaultRetryPolicy is a reasonable policy for Steam
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseBackoff: 500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// backoff is the wait before the attempt after attempt, with jitter
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// SetRetryPolicy turns on retries for all the services of the Client, nil
+// turns them off.
+func (c *Client) SetRetryPolicy(p *RetryPolicy) {
+	c.retry.set(p)
+}
+
+// retrier holds the policy shared by every retryDoer of a Client
+type retrier struct {
+	mu     sync.RWMutex
+	policy *RetryPolicy
+}
+
+func (r *retrier) set(p *RetryPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p != nil {
+		cp := *p
+		p = &cp
+	}
+	r.policy = p
+}
+
+func (r *retrier) get() *RetryPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.policy
+}
+
+type attemptsKey struct{}
+
+// Attempts is how many times the request for resp was sent
+func Attempts(resp *http.Response) int {
+	if resp == nil || resp.Request == nil {
+		return 0
+	}
+
+	if n, ok := resp.Request.Context().Value(attemptsKey{}).(int); ok {
+		return n
+	}
+
+	return 1
+}
+
+// retryDoer sends a request again while the policy allows it
+type retryDoer struct {
+	doer    sling.Doer
+	retrier *retrier
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	p := d.retrier.get()
+	if p == nil || p.MaxAttempts <= 1 || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return d.doer.Do(req)
+	}
+
+	ctx := req.Context()
+	attempt := 1
+	for {
+		resp, err := d.doer.Do(req)
+
+		wait := p.backoff(attempt)
+		after, hasAfter := retryAfter(resp)
+		if hasAfter {
+			wait = after
+		}
+		// Give up instead of waiting longer than the policy allows
+		tooLong := hasAfter && p.MaxBackoff > 0 && after > p.MaxBackoff
+
+		if attempt >= p.MaxAttempts || ctx.Err() != nil || !shouldRetry(resp, err) || tooLong {
+			if resp != nil {
+				resp.Request = resp.Request.WithContext(context.WithValue(resp.Request.Context(), attemptsKey{}, attempt))
+			}
+			if err != nil && attempt > 1 {
+				err = fmt.Errorf("kettle: gave up after %d attempts: %w", attempt, err)
+			}
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		attempt++
+	}
+}
+
+// shouldRetry checks if the result of an attempt is worth retrying
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// retryAfter reads the Retry-After header, in seconds or as a date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	h := resp.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(h); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(h); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,123 @@
+package kettle
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientRetriesTransientFailures(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamappservice/getapplist.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/ISteamApps/GetAppList/v2/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+	})
+	apps, resp, err := client.ISteamAppsService.GetAppList()
+
+	assert.Nil(t, err)
+	assert.Len(t, apps, 5)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 3, Attempts(resp))
+}
+
+func TestClientRetriesGiveUp(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/api/appdetails", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client := NewClient(httpClient, "")
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 2,
+		BaseBackoff: time.Millisecond,
+	})
+	_, _, err := client.Store.AppDetails(1)
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, 2, apiErr.Attempts)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClientRetriesGiveUpOnLongRetryAfter(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/ISteamApps/GetAppList/v2/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client := NewClient(httpClient, "")
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  time.Second,
+	})
+
+	start := time.Now()
+	_, resp, err := client.ISteamAppsService.GetAppList()
+
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, Attempts(resp))
+}
+
+func TestClientDoesNotRetryByDefault(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/ISteamApps/GetAppList/v2/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	client := NewClient(httpClient, "")
+	_, resp, err := client.ISteamAppsService.GetAppList()
+
+	assert.True(t, errors.Is(err, ErrServerError))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, Attempts(resp))
+}
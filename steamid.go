@@ -0,0 +1,277 @@
+package kettle
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SteamID identifies a Steam account, group or server. It's stored as a
+// SteamID64 and can be parsed from and rendered as every other format.
+type SteamID uint64
+
+// Universe is the Steam universe part of a SteamID
+type Universe int
+
+// The options for Universe
+const (
+	UniverseInvalid  = Universe(0)
+	UniversePublic   = Universe(1)
+	UniverseBeta     = Universe(2)
+	UniverseInternal = Universe(3)
+	UniverseDev      = Universe(4)
+)
+
+// AccountType is the type of account a SteamID belongs to
+type AccountType int
+
+// The options for AccountType
+const (
+	AccountTypeInvalid        = AccountType(0)
+	AccountTypeIndividual     = AccountType(1)
+	AccountTypeMultiseat      = AccountType(2)
+	AccountTypeGameServer     = AccountType(3)
+	AccountTypeAnonGameServer = AccountType(4)
+	AccountTypePending        = AccountType(5)
+	AccountTypeContentServer  = AccountType(6)
+	AccountTypeClan           = AccountType(7)
+	AccountTypeChat           = AccountType(8)
+	AccountTypeConsoleUser    = AccountType(9)
+	AccountTypeAnonUser       = AccountType(10)
+)
+
+// DesktopInstance is the instance used by individual accounts
+const DesktopInstance = 1
+
+// Flags in the instance of a chat SteamID, shown as c and L in Steam3
+const (
+	ChatInstanceFlagClan  = 0x80000
+	ChatInstanceFlagLobby = 0x40000
+)
+
+// ErrInvalidSteamID is returned when a SteamID can't be parsed
+var ErrInvalidSteamID = errors.New("kettle: invalid steam id")
+
+// steam3Letters maps account types to the letter used in the Steam3 format
+var steam3Letters = map[AccountType]string{
+	AccountTypeInvalid:        "I",
+	AccountTypeIndividual:     "U",
+	AccountTypeMultiseat:      "M",
+	AccountTypeGameServer:     "G",
+	AccountTypeAnonGameServer: "A",
+	AccountTypePending:        "P",
+	AccountTypeContentServer:  "C",
+	AccountTypeClan:           "g",
+	AccountTypeChat:           "T",
+	AccountTypeAnonUser:       "a",
+}
+
+var (
+	steam2Regexp = regexp.MustCompile(`^STEAM_([0-4]):([01]):(\d+)$`)
+	steam3Regexp = regexp.MustCompile(`^\[([IUMGAPCgTLca]):([0-4]):(\d+)(?::(\d+))?\]$`)
+)
+
+// NewSteamID creates a SteamID from its parts
+func NewSteamID(universe Universe, accountType AccountType, instance uint32, accountID uint32) SteamID {
+	return SteamID(uint64(universe)<<56 |
+		uint64(accountType&0xF)<<52 |
+		uint64(instance&0xFFFFF)<<32 |
+		uint64(accountID))
+}
+
+// SteamIDFromAccountID creates the SteamID of an individual public account
+func SteamIDFromAccountID(accountID uint32) SteamID {
+	return NewSteamID(UniversePublic, AccountTypeIndividual, DesktopInstance, accountID)
+}
+
+// ParseSteamID parses a SteamID64 (76561197960287930), Steam2
+// (STEAM_0:0:11101), Steam3 ([U:1:22202]) or account ID (22202). An account
+// ID is taken as an individual public account. The result has to be valid.
+func ParseSteamID(s string) (SteamID, error) {
+	id, err := parseSteamID(strings.TrimSpace(s))
+	if err != nil || !id.IsValid() {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidSteamID, s)
+	}
+
+	return id, nil
+}
+
+func parseSteamID(s string) (SteamID, error) {
+	if m := steam2Regexp.FindStringSubmatch(s); m != nil {
+		universe, _ := strconv.Atoi(m[1])
+		y, _ := strconv.ParseUint(m[2], 10, 32)
+		z, err := strconv.ParseUint(m[3], 10, 31)
+		if err != nil {
+			return 0, err
+		}
+		// Old games use 0 for the public universe
+		if universe == 0 {
+			universe = int(UniversePublic)
+		}
+		return NewSteamID(Universe(universe), AccountTypeIndividual, DesktopInstance, uint32(z<<1|y)), nil
+	}
+
+	if m := steam3Regexp.FindStringSubmatch(s); m != nil {
+		universe, _ := strconv.Atoi(m[2])
+		accountID, err := strconv.ParseUint(m[3], 10, 32)
+		if err != nil {
+			return 0, err
+		}
+
+		var accountType AccountType
+		instance := uint64(0)
+		if m[1] == "U" {
+			instance = DesktopInstance
+		}
+		if m[4] != "" {
+			if instance, err = strconv.ParseUint(m[4], 10, 20); err != nil {
+				return 0, err
+			}
+		}
+
+		switch m[1] {
+		case "c":
+			accountType = AccountTypeChat
+			instance |= ChatInstanceFlagClan
+		case "L":
+			accountType = AccountTypeChat
+			instance |= ChatInstanceFlagLobby
+		default:
+			for t, letter := range steam3Letters {
+				if letter == m[1] {
+					accountType = t
+				}
+			}
+		}
+
+		return NewSteamID(Universe(universe), accountType, uint32(instance), uint32(accountID)), nil
+	}
+
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0xFFFFFFFF {
+		return SteamIDFromAccountID(uint32(n)), nil
+	}
+
+	return SteamID(n), nil
+}
+
+// Universe is the universe the SteamID is in
+func (id SteamID) Universe() Universe {
+	return Universe(uint64(id) >> 56)
+}
+
+// AccountType is the type of account for the SteamID
+func (id SteamID) AccountType() AccountType {
+	return AccountType((uint64(id) >> 52) & 0xF)
+}
+
+// Instance is the instance of the SteamID, 1 for individual accounts
+func (id SteamID) Instance() uint32 {
+	return uint32((uint64(id) >> 32) & 0xFFFFF)
+}
+
+// AccountID is the 32 bit account number of the SteamID
+func (id SteamID) AccountID() uint32 {
+	return uint32(id)
+}
+
+// IsValid checks the parts of the SteamID make sense together
+func (id SteamID) IsValid() bool {
+	if id.Universe() <= UniverseInvalid || id.Universe() > UniverseDev {
+		return false
+	}
+
+	switch id.AccountType() {
+	case AccountTypeInvalid:
+		return false
+	case AccountTypeIndividual:
+		return id.AccountID() != 0 && id.Instance() <= 4
+	case AccountTypeClan:
+		return id.AccountID() != 0 && id.Instance() == 0
+	case AccountTypeGameServer:
+		return id.AccountID() != 0
+	}
+
+	return id.AccountType() <= AccountTypeAnonUser
+}
+
+// String renders the SteamID64
+func (id SteamID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// Steam2 renders the SteamID as STEAM_X:Y:Z
+func (id SteamID) Steam2() string {
+	return fmt.Sprintf("STEAM_%d:%d:%d", id.Universe(), id.AccountID()&1, id.AccountID()>>1)
+}
+
+// Steam3 renders the SteamID as [U:1:22202]
+func (id SteamID) Steam3() string {
+	letter, ok := steam3Letters[id.AccountType()]
+	if !ok {
+		letter = "i"
+	}
+	if id.AccountType() == AccountTypeChat {
+		switch {
+		case id.Instance()&ChatInstanceFlagClan != 0:
+			letter = "c"
+		case id.Instance()&ChatInstanceFlagLobby != 0:
+			letter = "L"
+		}
+	}
+
+	switch {
+	case id.AccountType() == AccountTypeAnonGameServer, id.AccountType() == AccountTypeMultiseat:
+		return fmt.Sprintf("[%s:%d:%d:%d]", letter, id.Universe(), id.AccountID(), id.Instance())
+	case id.AccountType() == AccountTypeIndividual && id.Instance() != DesktopInstance:
+		return fmt.Sprintf("[%s:%d:%d:%d]", letter, id.Universe(), id.AccountID(), id.Instance())
+	}
+
+	return fmt.Sprintf("[%s:%d:%d]", letter, id.Universe(), id.AccountID())
+}
+
+// MarshalText renders the SteamID64, Steam uses strings for them in JSON
+func (id SteamID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText parses any format ParseSteamID understands
+func (id *SteamID) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*id = 0
+		return nil
+	}
+
+	// SteamID64s from Steam are taken as they are, even ones that aren't valid
+	if n, err := strconv.ParseUint(string(b), 10, 64); err == nil && (n == 0 || n > 0xFFFFFFFF) {
+		*id = SteamID(n)
+		return nil
+	}
+
+	parsed, err := ParseSteamID(string(b))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
+
+// UnmarshalJSON accepts the SteamID as a string or a number
+func (id *SteamID) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	return id.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,87 @@
+package kettle
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSteamID(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"76561197960287930",
+		"STEAM_0:0:11101",
+		"STEAM_1:0:11101",
+		"[U:1:22202]",
+		"22202",
+	}
+
+	for _, test := range tests {
+		id, err := ParseSteamID(test)
+		assert.Nil(t, err, test)
+		assert.Equal(t, SteamID(76561197960287930), id, test)
+	}
+
+	id, err := ParseSteamID("[g:1:4]")
+	assert.Nil(t, err)
+	assert.Equal(t, SteamID(103582791429521412), id)
+	assert.Equal(t, AccountTypeClan, id.AccountType())
+
+	for _, bad := range []string{"", "abc", "STEAM_0:2:1", "[X:1:1]", "76561197960287930000", "0", "[I:1:0]", "[U:7:1]", "[U:0:1]", "STEAM_1:0:0"} {
+		_, err := ParseSteamID(bad)
+		assert.True(t, errors.Is(err, ErrInvalidSteamID), bad)
+	}
+}
+
+func TestParseSteamIDChats(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []string{"[c:1:123]", "[L:1:123]", "[T:1:123]"} {
+		id, err := ParseSteamID(test)
+		assert.Nil(t, err, test)
+		assert.Equal(t, AccountTypeChat, id.AccountType(), test)
+		assert.Equal(t, test, id.Steam3())
+	}
+
+	id, _ := ParseSteamID("[c:1:123]")
+	assert.Equal(t, uint32(ChatInstanceFlagClan), id.Instance())
+	id, _ = ParseSteamID("[L:1:123]")
+	assert.Equal(t, uint32(ChatInstanceFlagLobby), id.Instance())
+}
+
+func TestSteamIDFormats(t *testing.T) {
+	t.Parallel()
+	id := SteamID(76561197960287930)
+
+	assert.True(t, id.IsValid())
+	assert.Equal(t, UniversePublic, id.Universe())
+	assert.Equal(t, AccountTypeIndividual, id.AccountType())
+	assert.Equal(t, uint32(1), id.Instance())
+	assert.Equal(t, uint32(22202), id.AccountID())
+	assert.Equal(t, "76561197960287930", id.String())
+	assert.Equal(t, "STEAM_1:0:11101", id.Steam2())
+	assert.Equal(t, "[U:1:22202]", id.Steam3())
+	assert.Equal(t, "[g:1:4]", SteamID(103582791429521412).Steam3())
+
+	assert.False(t, SteamID(0).IsValid())
+}
+
+func TestSteamIDJSON(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		A SteamID `json:"a"`
+		B SteamID `json:"b"`
+	}
+	err := json.Unmarshal([]byte(`{"a":"76561197960287930","b":76561197960287930}`), &v)
+	assert.Nil(t, err)
+	assert.Equal(t, SteamID(76561197960287930), v.A)
+	assert.Equal(t, SteamID(76561197960287930), v.B)
+
+	b, err := json.Marshal(v)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":"76561197960287930","b":"76561197960287930"}`, string(b))
+}
@@ -1,9 +1,14 @@
 package kettle
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"encoding/json"
 
@@ -12,96 +17,277 @@ import (
 
 // StoreService provides a method for accessing Steam store endpoints
 type StoreService struct {
-	sling *sling.Sling
+	sling    *sling.Sling
+	language string
+	country  string
 }
 
-func newStoreService(sling *sling.Sling) *StoreService {
+func newStoreService(sling *sling.Sling, language, country string) *StoreService {
 	return &StoreService{
-		sling: sling,
+		sling:    sling,
+		language: language,
+		country:  country,
 	}
 }
 
 type appDetails struct {
-	Success bool    `json:"success"`
-	AppData AppData `json:"data"`
+	Success bool            `json:"success"`
+	AppData json.RawMessage `json:"data"`
+}
+
+// decode returns the AppData, Steam sends an empty array when filters are
+// used and none of the filtered data exists
+func (d appDetails) decode() (*AppData, error) {
+	a := new(AppData)
+	if len(d.AppData) == 0 || d.AppData[0] != '{' {
+		return a, nil
+	}
+
+	err := json.Unmarshal(d.AppData, a)
+	return a, err
 }
 
 // AppData holds the data for StoreService.AppDetails
 // https://wiki.teamfortress.com/wiki/User:RJackson/StorefrontAPI#appdetails
 type AppData struct {
-	Type                string          `json:"type"`
-	Name                string          `json:"name"`
-	SteamAppID          int64           `json:"steam_appid"`
-	RequiredAge         interface{}     `json:"required_age"` // Can be string or int
-	IsFree              bool            `json:"is_free"`
-	ControllerSupport   string          `json:"controller_support"`
-	Dlc                 []int64         `json:"dlc,omitempty"`
-	DetailedDescription string          `json:"detailed_description"`
-	AboutTheGame        string          `json:"about_the_game"`
-	ShortDescription    string          `json:"short_description"`
-	SupportedLanguages  string          `json:"supported_languages"`
-	Reviews             string          `json:"reviews"`
-	HeaderImage         string          `json:"header_image"`
-	Website             string          `json:"website"`
-	PCRequirements      json.RawMessage `json:"pc_requirements,omitempty"`
-	MacRequirements     json.RawMessage `json:"mac_requirements,omitempty"`
-	LinuxRequirements   json.RawMessage `json:"linux_requirements,omitempty"`
-	LegalNotice         string          `json:"legal_notice"`
-	Developers          []string        `json:"developers"`
-	Publishers          []string        `json:"publishers"`
-	PriceOverview       Price           `json:"price_overview"`
-	Packages            json.RawMessage `json:"packages"` // Can be an array of strings or ints
-	PackageGroups       []PackageGroup  `json:"package_groups"`
-	Platforms           Platform        `json:"platforms"`
-	MetaCritic          MetaCritic      `json:"metacritic,omitempty"`
-	Categories          []Category      `json:"categories"`
-	Genres              []Genre         `json:"genres"`
-	Screenshots         []Screenshot    `json:"screenshots"`
-	Movies              []Movie         `json:"movies"`
-	Recomendations      Recomendations  `json:"recommendations"`
-	Achievements        Achievements    `json:"achievements"`
-	ReleaseDate         ReleaseDate     `json:"release_date"`
-	SupportInfo         SupportInfo     `json:"support_info"`
-	Background          string          `json:"background"`
-}
-
-// Requirements is possibly used for pc/mac/linux requirements. If it's empty
-// it will be an empty array so you will need to cast this yourself.
+	Type                string         `json:"type"`
+	Name                string         `json:"name"`
+	SteamAppID          int64          `json:"steam_appid"`
+	RequiredAge         int            `json:"required_age"`
+	IsFree              bool           `json:"is_free"`
+	ControllerSupport   string         `json:"controller_support"`
+	Dlc                 []int64        `json:"dlc,omitempty"`
+	DetailedDescription string         `json:"detailed_description"`
+	AboutTheGame        string         `json:"about_the_game"`
+	ShortDescription    string         `json:"short_description"`
+	SupportedLanguages  string         `json:"supported_languages"`
+	Reviews             string         `json:"reviews"`
+	HeaderImage         string         `json:"header_image"`
+	Website             string         `json:"website"`
+	PCRequirements      *Requirements  `json:"pc_requirements,omitempty"`
+	MacRequirements     *Requirements  `json:"mac_requirements,omitempty"`
+	LinuxRequirements   *Requirements  `json:"linux_requirements,omitempty"`
+	LegalNotice         string         `json:"legal_notice"`
+	Developers          []string       `json:"developers"`
+	Publishers          []string       `json:"publishers"`
+	PriceOverview       Price          `json:"price_overview"`
+	Packages            []int64        `json:"packages"`
+	PackageGroups       []PackageGroup `json:"package_groups"`
+	Platforms           Platform       `json:"platforms"`
+	MetaCritic          MetaCritic     `json:"metacritic,omitempty"`
+	Categories          []Category     `json:"categories"`
+	Genres              []Genre        `json:"genres"`
+	Screenshots         []Screenshot   `json:"screenshots"`
+	Movies              []Movie        `json:"movies"`
+	Recomendations      Recomendations `json:"recommendations"`
+	Achievements        Achievements   `json:"achievements"`
+	ReleaseDate         ReleaseDate    `json:"release_date"`
+	SupportInfo         SupportInfo    `json:"support_info"`
+	Background          string         `json:"background"`
+}
+
+// UnmarshalJSON normalizes the fields Steam sends in more than one shape
+func (a *AppData) UnmarshalJSON(b []byte) error {
+	type appData AppData
+	aux := struct {
+		*appData
+		RequiredAge       json.RawMessage `json:"required_age"`
+		PCRequirements    json.RawMessage `json:"pc_requirements"`
+		MacRequirements   json.RawMessage `json:"mac_requirements"`
+		LinuxRequirements json.RawMessage `json:"linux_requirements"`
+		Packages          json.RawMessage `json:"packages"`
+	}{
+		appData: (*appData)(a),
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	age, err := flexInt(aux.RequiredAge)
+	if err != nil {
+		return err
+	}
+	a.RequiredAge = int(age)
+
+	if a.PCRequirements, err = requirements(aux.PCRequirements); err != nil {
+		return err
+	}
+	if a.MacRequirements, err = requirements(aux.MacRequirements); err != nil {
+		return err
+	}
+	if a.LinuxRequirements, err = requirements(aux.LinuxRequirements); err != nil {
+		return err
+	}
+
+	a.Packages, err = flexInts(aux.Packages)
+	return err
+}
+
+// Requirements are the pc/mac/linux requirements, nil when an app has none
 type Requirements struct {
 	Minimum     string `json:"minimum"`
 	Recommended string `json:"recommended"`
 }
 
+// requirements decodes requirements sent as an object, a string or an empty
+// array when there are none
+func requirements(raw json.RawMessage) (*Requirements, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	switch raw[0] {
+	case '{':
+		r := new(Requirements)
+		err := json.Unmarshal(raw, r)
+		return r, err
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		if s == "" {
+			return nil, nil
+		}
+		return &Requirements{Minimum: s}, nil
+	}
+
+	return nil, nil
+}
+
+// flexInt decodes a number Steam sends as a number or a string. Empty
+// strings and null are 0 and anything after the digits, like the + in "18+",
+// is ignored.
+func flexInt(raw json.RawMessage) (int64, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, nil
+	}
+
+	s := string(raw)
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return 0, err
+		}
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	end := 0
+	if s[0] == '-' {
+		end = 1
+	}
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+
+	n, err := strconv.ParseInt(s[:end], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("kettle: %q is not a number", s)
+	}
+
+	return n, nil
+}
+
+// flexInts decodes an array of numbers that can be numbers or strings
+func flexInts(raw json.RawMessage) ([]int64, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+
+	ints := make([]int64, len(items))
+	for i, item := range items {
+		n, err := flexInt(item)
+		if err != nil {
+			return nil, err
+		}
+		ints[i] = n
+	}
+
+	return ints, nil
+}
+
 // Price holds the current and sale price for an app
 type Price struct {
-	Currency        string `json:"currency"`
-	Initial         int    `json:"initial"`
-	Final           int    `json:"final"`
-	DiscountPercent int    `json:"discount_percent"`
+	Currency         string `json:"currency"`
+	Initial          int    `json:"initial"`
+	Final            int    `json:"final"`
+	DiscountPercent  int    `json:"discount_percent"`
+	InitialFormatted string `json:"initial_formatted"`
+	FinalFormatted   string `json:"final_formatted"`
 }
 
 // PackageGroup are the packages the app is part of
 type PackageGroup struct {
-	Name                    string      `json:"name"`
-	Title                   string      `json:"title"`
-	Description             string      `json:"description"`
-	SelectionText           string      `json:"selection_text"`
-	SaveText                string      `json:"save_text"`
-	DisplayType             interface{} `json:"display_type"` //This can be a string or number
-	IsRecurringSubscription string      `json:"is_recurring_subscription"`
-	Subs                    []Sub       `json:"subs"`
+	Name                    string `json:"name"`
+	Title                   string `json:"title"`
+	Description             string `json:"description"`
+	SelectionText           string `json:"selection_text"`
+	SaveText                string `json:"save_text"`
+	DisplayType             int    `json:"display_type"`
+	IsRecurringSubscription string `json:"is_recurring_subscription"`
+	Subs                    []Sub  `json:"subs"`
+}
+
+// UnmarshalJSON accepts DisplayType as a string or a number
+func (g *PackageGroup) UnmarshalJSON(b []byte) error {
+	type packageGroup PackageGroup
+	aux := struct {
+		*packageGroup
+		DisplayType json.RawMessage `json:"display_type"`
+	}{
+		packageGroup: (*packageGroup)(g),
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	displayType, err := flexInt(aux.DisplayType)
+	g.DisplayType = int(displayType)
+	return err
 }
 
 // Sub is part of the PackageGroup, details about a package
 type Sub struct {
-	PackageID                json.RawMessage `json:"packageid"` // This could be a string or int64
-	PercentSavingsText       string          `json:"percent_savings_text"`
-	PercentSavings           int             `json:"percent_savings"`
-	OptionText               string          `json:"option_text"`
-	OptionDescription        string          `json:"option_description"`
-	CanGetFreeLicense        string          `json:"can_get_free_license"`
-	IsFreeLicense            bool            `json:"is_free_license"`
-	PriceInCentsWithDiscount int             `json:"price_in_cents_with_discount"`
+	PackageID                int64  `json:"packageid"`
+	PercentSavingsText       string `json:"percent_savings_text"`
+	PercentSavings           int    `json:"percent_savings"`
+	OptionText               string `json:"option_text"`
+	OptionDescription        string `json:"option_description"`
+	CanGetFreeLicense        string `json:"can_get_free_license"`
+	IsFreeLicense            bool   `json:"is_free_license"`
+	PriceInCentsWithDiscount int    `json:"price_in_cents_with_discount"`
+}
+
+// UnmarshalJSON accepts PackageID as a string or a number
+func (s *Sub) UnmarshalJSON(b []byte) error {
+	type sub Sub
+	aux := struct {
+		*sub
+		PackageID json.RawMessage `json:"packageid"`
+	}{
+		sub: (*sub)(s),
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	s.PackageID, err = flexInt(aux.PackageID)
+	return err
 }
 
 // Platform lists what platforms this app works on
@@ -174,6 +360,11 @@ type ReleaseDate struct {
 	Date       string `json:"date"`
 }
 
+// Time parses Date, see ParseReleaseDate
+func (r ReleaseDate) Time() (time.Time, DatePrecision) {
+	return ParseReleaseDate(r.Date)
+}
+
 // SupportInfo holds contact info for support for an app
 type SupportInfo struct {
 	URL   string `json:"url"`
@@ -181,31 +372,202 @@ type SupportInfo struct {
 }
 
 // AppDetails gets detailed information about a game
-// Not supported: multiple appids with &filters=price_overview
+// For multiple appids use AppDetailsBatch
 // https://wiki.teamfortress.com/wiki/User:RJackson/StorefrontAPI#appdetails
 func (s *StoreService) AppDetails(id int64) (*AppData, *http.Response, error) {
+	return s.AppDetailsWithContext(context.Background(), id)
+}
+
+// AppDetailsWithContext is AppDetails with a context for cancellation
+func (s *StoreService) AppDetailsWithContext(ctx context.Context, id int64) (*AppData, *http.Response, error) {
+	return s.AppDetailsWithParamsContext(ctx, &AppDetailsParams{AppID: id})
+}
+
+// AppDetailsParams are the parameters for StoreService.AppDetailsWithParams
+// Country and Language are the client defaults if empty. Country changes the
+// currency of PriceOverview and Language the language of the descriptions.
+type AppDetailsParams struct {
+	AppID    int64
+	Country  string   // Two letter country code, e.g. "DE"
+	Language string   // e.g. "german"
+	Filters  []string // Only return these parts of AppData, e.g. FilterPriceOverview
+}
+
+// AppDetailsWithParams gets detailed information about a game for a store
+// region and language
+// https://wiki.teamfortress.com/wiki/User:RJackson/StorefrontAPI#appdetails
+func (s *StoreService) AppDetailsWithParams(params *AppDetailsParams) (*AppData, *http.Response, error) {
+	return s.AppDetailsWithParamsContext(context.Background(), params)
+}
+
+// AppDetailsWithParamsContext is AppDetailsWithParams with a context for cancellation
+func (s *StoreService) AppDetailsWithParamsContext(ctx context.Context, params *AppDetailsParams) (*AppData, *http.Response, error) {
 	response := make(map[string]appDetails)
 
-	resp, err := s.sling.New().Path("api/appdetails").QueryStruct(struct {
-		AppIDs int64 `url:"appids"`
+	country := params.Country
+	if country == "" {
+		country = s.country
+	}
+
+	language := params.Language
+	if language == "" {
+		language = s.language
+	}
+
+	resp, err := receive(ctx, s.sling.New().Path("api/appdetails").QueryStruct(struct {
+		AppIDs   int64  `url:"appids"`
+		Language string `url:"l,omitempty"`
+		Country  string `url:"cc,omitempty"`
+		Filters  string `url:"filters,omitempty"`
 	}{
-		AppIDs: id,
-	}).Receive(&response, &response)
+		AppIDs:   params.AppID,
+		Language: language,
+		Country:  country,
+		Filters:  strings.Join(params.Filters, ","),
+	}), &response, &response)
+
+	i := strconv.FormatInt(params.AppID, 10)
+	a, decodeErr := response[i].decode()
+	if err == nil {
+		err = decodeErr
+	}
+
+	if err == nil && !response[i].Success {
+		apiErr := newAPIError(resp, 0, "app details returned success = false")
+		apiErr.Err = ErrNotFound
+		err = apiErr
+	}
+
+	return a, resp, err
+}
 
-	i := strconv.FormatInt(id, 10)
-	a := response[i].AppData
+// Filters for the data returned by StoreService.AppDetailsWithParams and
+// StoreService.AppDetailsBatch
+const (
+	FilterBasic         = "basic"
+	FilterPriceOverview = "price_overview"
+	FilterPlatforms     = "platforms"
+	FilterCategories    = "categories"
+	FilterGenres        = "genres"
+	FilterReleaseDate   = "release_date"
+	FilterMetaCritic    = "metacritic"
+)
+
+// defaultBatchSize is how many appids are sent in one appdetails request
+const defaultBatchSize = 100
+
+// AppDetailsBatchParams are the parameters for StoreService.AppDetailsBatch
+// Steam only allows multiple appids with the price_overview filter, it's
+// used when Filters is empty. With any other filter every app is requested
+// on its own and BatchSize is ignored.
+type AppDetailsBatchParams struct {
+	AppIDs    []int64
+	Filters   []string
+	Country   string // Two letter country code, the client default if empty
+	Language  string // The client default if empty
+	BatchSize int    // AppIDs per request, 100 if 0
+}
+
+// AppDetailsBatch gets the details for many apps, the requests are split
+// into batches of BatchSize. The data for each app is in the first map and
+// the error for each app that failed is in the second. The error returned
+// is only set when the context is done.
+// https://wiki.teamfortress.com/wiki/User:RJackson/StorefrontAPI#appdetails
+func (s *StoreService) AppDetailsBatch(params *AppDetailsBatchParams) (map[int64]*AppData, map[int64]error, error) {
+	return s.AppDetailsBatchWithContext(context.Background(), params)
+}
+
+// AppDetailsBatchWithContext is AppDetailsBatch with a context for cancellation
+func (s *StoreService) AppDetailsBatchWithContext(ctx context.Context, params *AppDetailsBatchParams) (map[int64]*AppData, map[int64]error, error) {
+	apps := make(map[int64]*AppData)
+	errs := make(map[int64]error)
+
+	size := params.BatchSize
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+
+	filters := params.Filters
+	if len(filters) == 0 {
+		filters = []string{FilterPriceOverview}
+	}
+	for _, f := range filters {
+		if f != FilterPriceOverview {
+			size = 1
+		}
+	}
+
+	country := params.Country
+	if country == "" {
+		country = s.country
+	}
+
+	language := params.Language
+	if language == "" {
+		language = s.language
+	}
 
-	if !response[i].Success && err == nil {
-		err = errors.New("API request failed with Success = false")
+	for start := 0; start < len(params.AppIDs); start += size {
+		end := start + size
+		if end > len(params.AppIDs) {
+			end = len(params.AppIDs)
+		}
+		batch := params.AppIDs[start:end]
+
+		ids := make([]string, len(batch))
+		for i, id := range batch {
+			ids[i] = strconv.FormatInt(id, 10)
+		}
+
+		response := make(map[string]appDetails)
+
+		resp, err := receive(ctx, s.sling.New().Path("api/appdetails").QueryStruct(struct {
+			AppIDs   string `url:"appids"`
+			Filters  string `url:"filters"`
+			Country  string `url:"cc,omitempty"`
+			Language string `url:"l,omitempty"`
+		}{
+			AppIDs:   strings.Join(ids, ","),
+			Filters:  strings.Join(filters, ","),
+			Country:  country,
+			Language: language,
+		}), &response, nil)
+
+		if ctx.Err() != nil {
+			return apps, errs, ctx.Err()
+		}
+
+		for i, id := range batch {
+			if err != nil {
+				errs[id] = err
+				continue
+			}
+
+			details, ok := response[ids[i]]
+			if !ok || !details.Success {
+				apiErr := newAPIError(resp, 0, "app details returned success = false for "+ids[i])
+				apiErr.Err = ErrNotFound
+				errs[id] = apiErr
+				continue
+			}
+
+			a, decodeErr := details.decode()
+			if decodeErr != nil {
+				errs[id] = decodeErr
+				continue
+			}
+			apps[id] = a
+		}
 	}
 
-	return &a, resp, err
+	return apps, errs, nil
 }
 
 type AppReview struct {
 	Success      int          `json:"success"`
 	QuerySummary QuerySummary `json:"query_summary"`
 	Reviews      []Review     `json:"reviews"`
+	Cursor       string       `json:"cursor"` // Pass as AppReviewsParams.Cursor for the next page
 }
 
 type QuerySummary struct {
@@ -235,20 +597,37 @@ type Review struct {
 	EarlyAccess       bool            `json:"written_during_early_access"`
 }
 
+// CreatedTime is TimeCreated as a time.Time
+func (r Review) CreatedTime() time.Time {
+	return unixTime(r.TimeCreated)
+}
+
+// UpdatedTime is TimeUpdated as a time.Time
+func (r Review) UpdatedTime() time.Time {
+	return unixTime(r.TimeUpdated)
+}
+
 type Author struct {
-	UserID               string `json:"steamid"`
-	NumberGamesOwned     int    `json:"num_games_owned"`
-	NumberReviews        int    `json:"num_reviews"`
-	PlayTimeForever      int    `json:"playtime_forever"`
-	PlaytimeLastTwoWeeks int    `json:"playtime_last_two_weeks"`
-	LastPlayed           int64  `json:"last_played"`
+	UserID               SteamID `json:"steamid"`
+	NumberGamesOwned     int     `json:"num_games_owned"`
+	NumberReviews        int     `json:"num_reviews"`
+	PlayTimeForever      int     `json:"playtime_forever"`
+	PlaytimeLastTwoWeeks int     `json:"playtime_last_two_weeks"`
+	LastPlayed           int64   `json:"last_played"`
+}
+
+// LastPlayedTime is LastPlayed as a time.Time
+func (a Author) LastPlayedTime() time.Time {
+	return unixTime(a.LastPlayed)
 }
 
 // AppReviewsParams are the parameters for Store.AppReviews
 // https://partner.steamgames.com/doc/store/getreviews
 type AppReviewsParams struct {
-	JSON         int `url:"json"`
-	AppID        int64
+	JSON         int    `url:"json"`
+	AppID        int64  `url:"-"`
+	Cursor       string `url:"cursor,omitempty"`       // "*" for the first page
+	NumPerPage   int    `url:"num_per_page,omitempty"` // 20 if 0, at most 100
 	Filter       string `url:"filter,omitempty"`
 	Language     string `url:"language,omitempty"`
 	DayRange     string `url:"day_range,omitempty"`
@@ -260,17 +639,132 @@ type AppReviewsParams struct {
 // AppReviews gets review data for a game
 // https://partner.steamgames.com/doc/store/reviews
 func (s *StoreService) AppReviews(params *AppReviewsParams) (*AppReview, *http.Response, error) {
+	return s.AppReviewsWithContext(context.Background(), params)
+}
+
+// AppReviewsWithContext is AppReviews with a context for cancellation
+func (s *StoreService) AppReviewsWithContext(ctx context.Context, params *AppReviewsParams) (*AppReview, *http.Response, error) {
 	response := new(AppReview)
 
 	stringID := strconv.FormatInt(params.AppID, 10)
 
-	params.JSON = 1
+	p := *params
+	p.JSON = 1
+	if p.Language == "" {
+		p.Language = s.language
+	}
 
-	resp, err := s.sling.New().Path("appreviews/" + stringID).QueryStruct(params).ReceiveSuccess(response)
+	resp, err := receive(ctx, s.sling.New().Path("appreviews/"+stringID).QueryStruct(&p), response, nil)
 
-	if response.Success == 0 {
-		err = errors.New("API request for reviews failed with Success = 0")
+	if err == nil && response.Success == 0 {
+		err = newAPIError(resp, 0, "reviews returned success = 0")
 	}
 
 	return response, resp, err
 }
+
+// ErrCursorLoop is returned by ReviewIterator when Steam sends a cursor it
+// already sent
+var ErrCursorLoop = errors.New("kettle: review cursor loop")
+
+// ReviewIterator walks all the reviews of an app page by page
+//
+//	it := client.Store.ReviewIterator(&kettle.AppReviewsParams{AppID: 440}, 1000)
+//	for it.Next() {
+//		review := it.Review()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type ReviewIterator struct {
+	s      *StoreService
+	ctx    context.Context
+	params AppReviewsParams
+	limit  int
+	seen   map[string]bool
+	page   []Review
+	count  int
+	review Review
+	err    error
+	done   bool
+	loop   bool // The last page had a cursor that was already seen
+}
+
+// ReviewIterator returns an iterator over the reviews for params, it stops
+// after limit reviews, 0 means no limit
+func (s *StoreService) ReviewIterator(params *AppReviewsParams, limit int) *ReviewIterator {
+	return s.ReviewIteratorWithContext(context.Background(), params, limit)
+}
+
+// ReviewIteratorWithContext is ReviewIterator with a context for cancellation
+func (s *StoreService) ReviewIteratorWithContext(ctx context.Context, params *AppReviewsParams, limit int) *ReviewIterator {
+	p := *params
+	if p.Cursor == "" {
+		p.Cursor = "*"
+	}
+	if p.NumPerPage == 0 {
+		p.NumPerPage = 100
+	}
+
+	return &ReviewIterator{
+		s:      s,
+		ctx:    ctx,
+		params: p,
+		limit:  limit,
+		seen:   map[string]bool{p.Cursor: true},
+	}
+}
+
+// Next moves to the next review, it returns false when there are no more
+// reviews or there was an error
+func (it *ReviewIterator) Next() bool {
+	if it.done || (it.limit > 0 && it.count >= it.limit) {
+		return false
+	}
+
+	if len(it.page) == 0 {
+		if it.loop {
+			it.err = ErrCursorLoop
+			it.done = true
+			return false
+		}
+
+		response, _, err := it.s.AppReviewsWithContext(it.ctx, &it.params)
+		if err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+
+		if len(response.Reviews) == 0 {
+			it.done = true
+			return false
+		}
+
+		it.page = response.Reviews
+		it.loop = it.seen[response.Cursor]
+		it.seen[response.Cursor] = true
+		it.params.Cursor = response.Cursor
+	}
+
+	it.review = it.page[0]
+	it.page = it.page[1:]
+	it.count++
+
+	return true
+}
+
+// Review is the current review
+func (it *ReviewIterator) Review() Review {
+	return it.review
+}
+
+// Cursor is the cursor for the page after the current one, it can be used to
+// continue later
+func (it *ReviewIterator) Cursor() string {
+	return it.params.Cursor
+}
+
+// Err is the error that stopped the iterator
+func (it *ReviewIterator) Err() error {
+	return it.err
+}
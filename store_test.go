@@ -1,7 +1,9 @@
 package kettle
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"encoding/json"
@@ -38,9 +40,7 @@ func TestStoreServiceAppDetails(t *testing.T) {
 	assert.Equal(t, int64(289070), game.SteamAppID)
 	assert.Equal(t, false, game.IsFree)
 
-	v, ok := game.RequiredAge.(float64)
-	assert.True(t, ok)
-	assert.Equal(t, 18, int(v))
+	assert.Equal(t, 18, game.RequiredAge)
 
 	assert.Equal(t, []int64{512033, 512032}, game.Dlc)
 	assert.Equal(t, "<h1>D", game.DetailedDescription[0:5])
@@ -51,21 +51,13 @@ func TestStoreServiceAppDetails(t *testing.T) {
 	assert.Equal(t, "http://cdn.akamai.steamstatic.com/steam/apps/289070/header.jpg?t=1482279729", game.HeaderImage)
 	assert.Equal(t, "http://www.civilization.com/", game.Website)
 
-	pcReq := new(Requirements)
-	err = json.Unmarshal(game.PCRequirements, pcReq)
-	assert.Nil(t, err)
-	assert.Equal(t, "<strong>Minimum:", pcReq.Minimum[0:16])
-	assert.Equal(t, "<strong>Recommended:", pcReq.Recommended[0:20])
+	assert.Equal(t, "<strong>Minimum:", game.PCRequirements.Minimum[0:16])
+	assert.Equal(t, "<strong>Recommended:", game.PCRequirements.Recommended[0:20])
 
-	macReq := new(Requirements)
-	err = json.Unmarshal(game.MacRequirements, macReq)
-	assert.Nil(t, err)
-	assert.Equal(t, "<strong>Minimum:", macReq.Minimum[0:16])
-	assert.Equal(t, "", macReq.Recommended)
+	assert.Equal(t, "<strong>Minimum:", game.MacRequirements.Minimum[0:16])
+	assert.Equal(t, "", game.MacRequirements.Recommended)
 
-	linReq := new(Requirements)
-	err = json.Unmarshal(game.LinuxRequirements, linReq)
-	assert.NotNil(t, err)
+	assert.Nil(t, game.LinuxRequirements)
 
 	assert.Equal(t, "©2016 Tak", game.LegalNotice[0:10])
 
@@ -77,9 +69,8 @@ func TestStoreServiceAppDetails(t *testing.T) {
 	assert.Equal(t, 5999, game.PriceOverview.Final)
 	assert.Equal(t, 0, game.PriceOverview.DiscountPercent)
 
-	// can be a string or an int commit: 280d8a2315466e9fd336f86e45ab849744d01fb3
-	//assert.Len(t, game.Packages, 2)
-	//assert.Equal(t, 123215, game.Packages[0])
+	assert.Len(t, game.Packages, 2)
+	assert.Equal(t, int64(123215), game.Packages[0])
 
 	assert.Len(t, game.PackageGroups, 1)
 	assert.Equal(t, "default", game.PackageGroups[0].Name)
@@ -88,13 +79,11 @@ func TestStoreServiceAppDetails(t *testing.T) {
 	assert.Equal(t, "Select a purchase option", game.PackageGroups[0].SelectionText)
 	assert.Equal(t, "", game.PackageGroups[0].SaveText)
 
-	v, ok = game.PackageGroups[0].DisplayType.(float64)
-	assert.True(t, ok)
-	assert.Equal(t, 12, int(v))
+	assert.Equal(t, 12, game.PackageGroups[0].DisplayType)
 
 	assert.Equal(t, "false", game.PackageGroups[0].IsRecurringSubscription)
 
-	//assert.Equal(t, int64(123215), game.PackageGroups[0].Subs[0].PackageID)
+	assert.Equal(t, int64(123215), game.PackageGroups[0].Subs[0].PackageID)
 	assert.Equal(t, "", game.PackageGroups[0].Subs[0].PercentSavingsText)
 	assert.Equal(t, 0, game.PackageGroups[0].Subs[0].PercentSavings)
 	assert.Equal(t, "Sid Meier's Civilization VI - $59.99", game.PackageGroups[0].Subs[0].OptionText)
@@ -180,7 +169,7 @@ func TestStoreAppReviews(t *testing.T) {
 
 	assert.Equal(t, "32524002", reviewData.Reviews[0].ID)
 
-	assert.Equal(t, "76561198013832579", reviewData.Reviews[0].Author.UserID)
+	assert.Equal(t, SteamID(76561198013832579), reviewData.Reviews[0].Author.UserID)
 	assert.Equal(t, 72, reviewData.Reviews[0].Author.NumberGamesOwned)
 	assert.Equal(t, 6, reviewData.Reviews[0].Author.NumberReviews)
 	assert.Equal(t, 416, reviewData.Reviews[0].Author.PlayTimeForever)
@@ -203,3 +192,246 @@ func TestStoreAppReviews(t *testing.T) {
 	assert.Equal(t, false, reviewData.Reviews[0].ReceivedForFree)
 	assert.Equal(t, false, reviewData.Reviews[0].EarlyAccess)
 }
+
+func TestStoreServiceAppDetailsBatch(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/store/appdetails.batch.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	var requested []string
+	mux.HandleFunc("/api/appdetails", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assert.Equal(t, "price_overview", r.URL.Query().Get("filters"))
+		assert.Equal(t, "DE", r.URL.Query().Get("cc"))
+		requested = append(requested, r.URL.Query().Get("appids"))
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	apps, errs, err := client.Store.AppDetailsBatch(&AppDetailsBatchParams{
+		AppIDs:    []int64{289070, 440, 1},
+		Country:   "DE",
+		BatchSize: 2,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"289070,440", "1"}, requested)
+
+	assert.Len(t, apps, 2)
+	assert.Equal(t, "EUR", apps[289070].PriceOverview.Currency)
+	assert.Equal(t, 1499, apps[289070].PriceOverview.Final)
+	assert.Equal(t, 75, apps[289070].PriceOverview.DiscountPercent)
+	assert.Equal(t, "", apps[440].PriceOverview.Currency)
+
+	assert.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[1], ErrNotFound))
+}
+
+func TestStoreServiceAppDetailsBatchOtherFilters(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/store/appdetails.batch.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	var requested []string
+	mux.HandleFunc("/api/appdetails", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assert.Equal(t, "price_overview,basic", r.URL.Query().Get("filters"))
+		requested = append(requested, r.URL.Query().Get("appids"))
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	apps, errs, err := client.Store.AppDetailsBatch(&AppDetailsBatchParams{
+		AppIDs:    []int64{289070, 440, 1},
+		Filters:   []string{FilterPriceOverview, FilterBasic},
+		BatchSize: 100,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"289070", "440", "1"}, requested)
+	assert.Len(t, apps, 2)
+	assert.Len(t, errs, 1)
+}
+
+func TestStoreServiceAppDetailsWithParams(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/store/appdetails.batch.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/api/appdetails", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"appids":  "289070",
+			"cc":      "DE",
+			"l":       "german",
+			"filters": "price_overview,basic",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := New(WithHTTPClient(httpClient), WithLanguage("german"))
+	game, _, err := client.Store.AppDetailsWithParams(&AppDetailsParams{
+		AppID:   289070,
+		Country: "DE",
+		Filters: []string{FilterPriceOverview, FilterBasic},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "EUR", game.PriceOverview.Currency)
+	assert.Equal(t, 5999, game.PriceOverview.Initial)
+	assert.Equal(t, "14,99€", game.PriceOverview.FinalFormatted)
+}
+
+func reviewPage(cursor string, ids ...string) string {
+	reviews := make([]string, len(ids))
+	for i, id := range ids {
+		reviews[i] = `{"recommendationid":"` + id + `"}`
+	}
+
+	return `{"success":1,"cursor":"` + cursor + `","reviews":[` + strings.Join(reviews, ",") + `]}`
+}
+
+func TestStoreAppReviewsParamsNotChanged(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	languages := make(chan string, 2)
+	mux.HandleFunc("/appreviews/618690", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("json"))
+		languages <- r.URL.Query().Get("language")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":1}`))
+	})
+
+	params := &AppReviewsParams{AppID: 618690}
+
+	_, _, err := New(WithHTTPClient(httpClient), WithLanguage("german")).Store.AppReviews(params)
+	assert.Nil(t, err)
+	_, _, err = New(WithHTTPClient(httpClient), WithLanguage("french")).Store.AppReviews(params)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "german", <-languages)
+	assert.Equal(t, "french", <-languages)
+	assert.Equal(t, "", params.Language)
+	assert.Equal(t, 0, params.JSON)
+}
+
+func TestStoreServiceReviewIterator(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	pages := map[string]string{
+		"*":   reviewPage("A+1", "1", "2"),
+		"A+1": reviewPage("B", "3"),
+		"B":   reviewPage("B"),
+	}
+	mux.HandleFunc("/appreviews/440", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("num_per_page"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[r.URL.Query().Get("cursor")]))
+	})
+
+	client := NewClient(httpClient, "")
+	it := client.Store.ReviewIterator(&AppReviewsParams{AppID: 440}, 0)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Review().ID)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+
+	it = client.Store.ReviewIterator(&AppReviewsParams{AppID: 440}, 2)
+	ids = nil
+	for it.Next() {
+		ids = append(ids, it.Review().ID)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
+
+func TestStoreServiceReviewIteratorLoop(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	pages := map[string]string{
+		"*": reviewPage("A", "1"),
+		"A": reviewPage("A", "2"),
+	}
+	mux.HandleFunc("/appreviews/440", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[r.URL.Query().Get("cursor")]))
+	})
+
+	client := NewClient(httpClient, "")
+	it := client.Store.ReviewIterator(&AppReviewsParams{AppID: 440}, 0)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Review().ID)
+	}
+
+	assert.True(t, errors.Is(it.Err(), ErrCursorLoop))
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
+
+func TestAppDataUnmarshalShapes(t *testing.T) {
+	t.Parallel()
+
+	game := new(AppData)
+	err := json.Unmarshal([]byte(`{
+		"required_age": "18+",
+		"pc_requirements": {"minimum": "min"},
+		"mac_requirements": "",
+		"linux_requirements": [],
+		"packages": ["123", 456],
+		"package_groups": [{"display_type": "3", "subs": [{"packageid": "789"}, {"packageid": 10}]}]
+	}`), game)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 18, game.RequiredAge)
+	assert.Equal(t, "min", game.PCRequirements.Minimum)
+	assert.Nil(t, game.MacRequirements)
+	assert.Nil(t, game.LinuxRequirements)
+	assert.Equal(t, []int64{123, 456}, game.Packages)
+	assert.Equal(t, 3, game.PackageGroups[0].DisplayType)
+	assert.Equal(t, int64(789), game.PackageGroups[0].Subs[0].PackageID)
+	assert.Equal(t, int64(10), game.PackageGroups[0].Subs[1].PackageID)
+
+	err = json.Unmarshal([]byte(`{"required_age": "adult"}`), game)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,161 @@
+package kettle
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/dghubble/sling"
+)
+
+// IStoreService provides access to the catalog of the store
+type IStoreService struct {
+	sling *sling.Sling
+}
+
+func newIStoreService(sling *sling.Sling) *IStoreService {
+	return &IStoreService{
+		sling: sling.Path("IStoreService/"),
+	}
+}
+
+// StoreAppListParams are the parameters for IStoreService.GetAppList. Only
+// games are returned by default, IncludeGames can be set to False to leave
+// them out.
+type StoreAppListParams struct {
+	IncludeGames    *BoolAsAnInt `url:"include_games,omitempty"`
+	IncludeDLC      BoolAsAnInt  `url:"include_dlc,omitempty"`
+	IncludeSoftware BoolAsAnInt  `url:"include_software,omitempty"`
+	IncludeVideos   BoolAsAnInt  `url:"include_videos,omitempty"`
+	IncludeHardware BoolAsAnInt  `url:"include_hardware,omitempty"`
+	// IfModifiedSince only returns apps changed after this time
+	IfModifiedSince time.Time `url:"if_modified_since,omitempty,unix"`
+	// LastAppID is the LastAppID of the previous page
+	LastAppID int64 `url:"last_appid,omitempty"`
+	// MaxResults is the size of a page, Steam defaults to 10000 and allows 50000
+	MaxResults int `url:"max_results,omitempty"`
+}
+
+type storeAppListResponse struct {
+	Response StoreAppList `json:"response"`
+}
+
+// StoreAppList is a page of apps from IStoreService.GetAppList
+type StoreAppList struct {
+	Apps            []StoreApp `json:"apps"`
+	HaveMoreResults bool       `json:"have_more_results"`
+	LastAppID       int64      `json:"last_appid"`
+}
+
+// StoreApp is an app in a StoreAppList
+type StoreApp struct {
+	AppID             int64  `json:"appid"`
+	Name              string `json:"name"`
+	LastModified      int64  `json:"last_modified"`
+	PriceChangeNumber int64  `json:"price_change_number"`
+}
+
+// LastModifiedTime is LastModified as a time.Time
+func (a StoreApp) LastModifiedTime() time.Time {
+	return unixTime(a.LastModified)
+}
+
+// GetAppList returns a page of the apps in the store ordered by app id. This
+// needs an API key.
+// https://steamapi.xpaw.me/#IStoreService/GetAppList
+func (s *IStoreService) GetAppList(params *StoreAppListParams) (*StoreAppList, *http.Response, error) {
+	return s.GetAppListWithContext(context.Background(), params)
+}
+
+// GetAppListWithContext is GetAppList with a context for cancellation
+func (s *IStoreService) GetAppListWithContext(ctx context.Context, params *StoreAppListParams) (*StoreAppList, *http.Response, error) {
+	response := new(storeAppListResponse)
+
+	resp, err := receive(ctx, s.sling.New().Get("GetAppList/v1/").QueryStruct(params), response, nil)
+
+	return &response.Response, resp, err
+}
+
+// AppListIterator walks all the apps of IStoreService.GetAppList page by page
+//
+//	it := client.IStoreService.AppListIterator(&kettle.StoreAppListParams{IfModifiedSince: lastRun})
+//	for it.Next() {
+//		app := it.App()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type AppListIterator struct {
+	s      *IStoreService
+	ctx    context.Context
+	params StoreAppListParams
+	page   []StoreApp
+	app    StoreApp
+	err    error
+	done   bool
+	more   bool
+}
+
+// AppListIterator returns an iterator over the apps for params
+func (s *IStoreService) AppListIterator(params *StoreAppListParams) *AppListIterator {
+	return s.AppListIteratorWithContext(context.Background(), params)
+}
+
+// AppListIteratorWithContext is AppListIterator with a context for cancellation
+func (s *IStoreService) AppListIteratorWithContext(ctx context.Context, params *StoreAppListParams) *AppListIterator {
+	return &AppListIterator{
+		s:      s,
+		ctx:    ctx,
+		params: *params,
+		more:   true,
+	}
+}
+
+// Next moves to the next app, it returns false when there are no more apps
+// or there was an error
+func (it *AppListIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	for len(it.page) == 0 {
+		if !it.more {
+			it.done = true
+			return false
+		}
+
+		response, _, err := it.s.GetAppListWithContext(it.ctx, &it.params)
+		if err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+
+		// Stop when last_appid doesn't move forward so paging can't loop
+		it.more = response.HaveMoreResults && response.LastAppID > it.params.LastAppID
+		it.page = response.Apps
+		if response.LastAppID != 0 {
+			it.params.LastAppID = response.LastAppID
+		}
+	}
+
+	it.app = it.page[0]
+	it.page = it.page[1:]
+
+	return true
+}
+
+// App is the current app
+func (it *AppListIterator) App() StoreApp {
+	return it.app
+}
+
+// LastAppID is the LastAppID for the page after the current one, it can be
+// used to continue later
+func (it *AppListIterator) LastAppID() int64 {
+	return it.params.LastAppID
+}
+
+// Err is the error that stopped the iterator
+func (it *AppListIterator) Err() error {
+	return it.err
+}
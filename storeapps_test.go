@@ -0,0 +1,149 @@
+package kettle
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIStoreServiceGetAppList(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/istoreservice/getapplist.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/IStoreService/GetAppList/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"key":               "",
+			"include_games":     "0",
+			"include_dlc":       "1",
+			"include_software":  "1",
+			"if_modified_since": "1577836800",
+			"max_results":       "2",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	list, _, err := client.IStoreService.GetAppList(&StoreAppListParams{
+		IncludeGames:    BoolPtr(False),
+		IncludeDLC:      True,
+		IncludeSoftware: True,
+		IfModifiedSince: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		MaxResults:      2,
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, list.Apps, 2)
+	assert.True(t, list.HaveMoreResults)
+	assert.Equal(t, int64(20), list.LastAppID)
+	assert.Equal(t, int64(10), list.Apps[0].AppID)
+	assert.Equal(t, "Counter-Strike", list.Apps[0].Name)
+	assert.Equal(t, int64(13014447), list.Apps[0].PriceChangeNumber)
+	assert.Equal(t, time.Date(2020, time.October, 12, 20, 51, 33, 0, time.UTC), list.Apps[0].LastModifiedTime())
+}
+
+func TestIStoreServiceAppListIterator(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	requests := 0
+	mux.HandleFunc("/IStoreService/GetAppList/v1/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		filePath := "./json/istoreservice/getapplist.json"
+		if r.URL.Query().Get("last_appid") == "20" {
+			filePath = "./json/istoreservice/getapplist.last.json"
+		} else {
+			assertQuery(t, map[string]string{
+				"key":         "",
+				"max_results": "2",
+			}, r)
+		}
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	it := client.IStoreService.AppListIterator(&StoreAppListParams{MaxResults: 2})
+
+	var ids []int64
+	for it.Next() {
+		ids = append(ids, it.App().AppID)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []int64{10, 20, 30}, ids)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, int64(20), it.LastAppID())
+	assert.False(t, it.Next())
+}
+
+func TestIStoreServiceAppListIteratorError(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/IStoreService/GetAppList/v1/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	client := NewClient(httpClient, "")
+	it := client.IStoreService.AppListIterator(&StoreAppListParams{})
+
+	assert.False(t, it.Next())
+	assert.True(t, errors.Is(it.Err(), ErrInvalidKey))
+}
+
+func TestIStoreServiceAppListIteratorNotCached(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	requests := 0
+	mux.HandleFunc("/IStoreService/GetAppList/v1/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		filePath := "./json/istoreservice/getapplist.json"
+		if r.URL.Query().Get("last_appid") == "20" {
+			filePath = "./json/istoreservice/getapplist.last.json"
+		}
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := New(WithHTTPClient(httpClient), WithCache(NewMemoryCache(10)))
+	for i := 0; i < 2; i++ {
+		it := client.IStoreService.AppListIterator(&StoreAppListParams{MaxResults: 2})
+		for it.Next() {
+		}
+		assert.Nil(t, it.Err())
+	}
+
+	assert.Equal(t, 4, requests)
+}
@@ -1,8 +1,12 @@
 package kettle
 
 import (
+	"context"
+	"errors"
 	"net/http"
-	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/dghubble/sling"
 )
@@ -28,25 +32,41 @@ type friendsList struct {
 
 // Friend is a steam users's friend.
 type Friend struct {
-	SteamID      string `json:"steamid"`
-	Relationship string `json:"relationship"`
-	FriendSince  int64  `json:"friend_since"`
+	SteamID      SteamID `json:"steamid"`
+	Relationship string  `json:"relationship"`
+	FriendSince  int64   `json:"friend_since"`
+}
+
+// FriendSinceTime is FriendSince as a time.Time
+func (f Friend) FriendSinceTime() time.Time {
+	return unixTime(f.FriendSince)
 }
 
 // GetFriendListParams are the parameters for ISteamUserService.GetFriendList
 // Relatiionship (optional) can be "friend" or "all".
 type GetFriendListParams struct {
-	SteamID      int64  `url:"steamid"`
-	Relationship string `url:"relationship,omitempty"`
+	SteamID      SteamID `url:"steamid"`
+	Relationship string  `url:"relationship,omitempty"`
 }
 
 // GetFriendList Returns friends Steam user if profile is public.
 // https://developer.valvesoftware.com/wiki/Steam_Web_API#GetFriendList_.28v0001.29
 // https://wiki.teamfortress.com/wiki/WebAPI/GetFriendList
 func (s *ISteamUserService) GetFriendList(params *GetFriendListParams) ([]Friend, *http.Response, error) {
+	return s.GetFriendListWithContext(context.Background(), params)
+}
+
+// GetFriendListWithContext is GetFriendList with a context for cancellation
+func (s *ISteamUserService) GetFriendListWithContext(ctx context.Context, params *GetFriendListParams) ([]Friend, *http.Response, error) {
 	response := new(friendListResponse)
 
-	resp, err := s.sling.New().Get("GetFriendList/v1/").QueryStruct(params).ReceiveSuccess(response)
+	resp, err := receive(ctx, s.sling.New().Get("GetFriendList/v1/").QueryStruct(params), response, nil)
+
+	// Steam responds with 401 when the friend list isn't public
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+		apiErr.Err = ErrPrivateProfile
+	}
 
 	return response.FriendsList.Friends, resp, err
 }
@@ -57,9 +77,9 @@ type vanityResponse struct {
 
 // VanityResponse is the response for ISteamUserService.ResolveVanityURL
 type VanityResponse struct {
-	SteamID string `json:"steamid"`
-	Success int    `json:"success"`
-	Message string `json:"message"`
+	SteamID SteamID `json:"steamid"`
+	Success int     `json:"success"`
+	Message string  `json:"message"`
 }
 
 // ResolveVanityURLParams the parameters for ISteamUserService.ResolveVanityURL
@@ -68,7 +88,7 @@ type ResolveVanityURLParams struct {
 	URLType   VanityType `url:"url_type,omitempty"`
 }
 
-//VanityType is the type of vanity url you're trying to resolve
+// VanityType is the type of vanity url you're trying to resolve
 type VanityType int
 
 // The options for VanityType
@@ -81,9 +101,20 @@ const (
 // ResolveVanityURL resolve a vanity url to a steam user id.
 // https://wiki.teamfortress.com/wiki/WebAPI/ResolveVanityURL
 func (s *ISteamUserService) ResolveVanityURL(params *ResolveVanityURLParams) (*VanityResponse, *http.Response, error) {
+	return s.ResolveVanityURLWithContext(context.Background(), params)
+}
+
+// ResolveVanityURLWithContext is ResolveVanityURL with a context for cancellation
+func (s *ISteamUserService) ResolveVanityURLWithContext(ctx context.Context, params *ResolveVanityURLParams) (*VanityResponse, *http.Response, error) {
 	response := new(vanityResponse)
 
-	resp, err := s.sling.New().Get("ResolveVanityURL/v1/").QueryStruct(params).ReceiveSuccess(response)
+	resp, err := receive(ctx, s.sling.New().Get("ResolveVanityURL/v1/").QueryStruct(params), response, nil)
+
+	if err == nil && response.VanityResponse.Success != 1 {
+		apiErr := newAPIError(resp, response.VanityResponse.Success, response.VanityResponse.Message)
+		apiErr.Err = ErrNotFound
+		err = apiErr
+	}
 
 	return &response.VanityResponse, resp, err
 }
@@ -98,46 +129,302 @@ type sResponse struct {
 
 // Player is a struct of extended details about a steam user
 type Player struct {
-	SteamID             string `json:"steamid"`
-	CommunityVisibility int    `json:"communityvisibilitystate"`
-	ProfileState        int    `json:"profilestate"`
-	PersonaName         string `json:"personaname"`
-	LastLogoff          int64  `json:"lastlogoff"`
-	ProfileURL          string `json:"profileurl"`
-	Avatar              string `json:"avatar"`
-	AvatarMedium        string `json:"avatarmedium"`
-	AvatarFull          string `json:"avatarfull"`
-	PersonaState        int    `json:"personastate"`
-	RealName            string `json:"realname"`
-	PrimaryClanID       string `json:"primaryclanid"`
-	TimeCreated         int64  `json:"timecreated"`
-	PersonaStateFlags   int    `json:"personastateflags"`
-	LocCountryCode      string `json:"loccountrycode"`
-	LocStateCode        string `json:"locstatecode"`
-	LocCityID           int    `json:"loccityid"`
-	GameID              string `json:"gameid"`
-	GameTitle           string `json:"gameextrainfo"`
+	SteamID             SteamID             `json:"steamid"`
+	CommunityVisibility CommunityVisibility `json:"communityvisibilitystate"`
+	ProfileState        ProfileState        `json:"profilestate"`
+	PersonaName         string              `json:"personaname"`
+	LastLogoff          int64               `json:"lastlogoff"`
+	ProfileURL          string              `json:"profileurl"`
+	Avatar              string              `json:"avatar"`
+	AvatarMedium        string              `json:"avatarmedium"`
+	AvatarFull          string              `json:"avatarfull"`
+	PersonaState        PersonaState        `json:"personastate"`
+	RealName            string              `json:"realname"`
+	PrimaryClanID       SteamID             `json:"primaryclanid"`
+	TimeCreated         int64               `json:"timecreated"`
+	PersonaStateFlags   PersonaStateFlags   `json:"personastateflags"`
+	LocCountryCode      string              `json:"loccountrycode"`
+	LocStateCode        string              `json:"locstatecode"`
+	LocCityID           int                 `json:"loccityid"`
+	GameID              string              `json:"gameid"`
+	GameTitle           string              `json:"gameextrainfo"`
+}
+
+// LastLogoffTime is LastLogoff as a time.Time
+func (p Player) LastLogoffTime() time.Time {
+	return unixTime(p.LastLogoff)
+}
+
+// CreatedTime is TimeCreated as a time.Time
+func (p Player) CreatedTime() time.Time {
+	return unixTime(p.TimeCreated)
 }
 
 // GetPlayerSummaries gets a full summary about a steam user
+// The ids are sent 100 at a time, the response is the last one.
 // https://developer.valvesoftware.com/wiki/Steam_Web_API#GetPlayerSummaries_.28v0002.29
 // https://wiki.teamfortress.com/wiki/WebAPI/GetPlayerSummaries
-func (s *ISteamUserService) GetPlayerSummaries(ids []int64) ([]Player, *http.Response, error) {
-	var pids string
-	for w, i := range ids {
-		pids += strconv.FormatInt(i, 10)
-		if w != len(ids)-1 {
-			pids += ","
+func (s *ISteamUserService) GetPlayerSummaries(ids []SteamID) ([]Player, *http.Response, error) {
+	return s.GetPlayerSummariesWithContext(context.Background(), ids)
+}
+
+// GetPlayerSummariesWithContext is GetPlayerSummaries with a context for cancellation
+func (s *ISteamUserService) GetPlayerSummariesWithContext(ctx context.Context, ids []SteamID) ([]Player, *http.Response, error) {
+	players, _, resp, err := s.playerSummaries(ctx, ids, 1)
+
+	return players, resp, err
+}
+
+// PlayerSummariesParams are the parameters for ISteamUserService.GetPlayerSummariesBatch
+type PlayerSummariesParams struct {
+	SteamIDs    []SteamID
+	Concurrency int // Requests of 100 ids sent at once, 1 if 0
+}
+
+// PlayerSummaries is the response for ISteamUserService.GetPlayerSummariesBatch
+type PlayerSummaries struct {
+	Players []Player
+	Missing []SteamID // Requested ids Steam didn't return a summary for
+}
+
+// GetPlayerSummariesBatch gets the summaries of any number of users and
+// reports the ones Steam didn't return. The requests still follow the rate
+// limit of the client when they're sent concurrently. The response is the
+// one for the last chunk of ids.
+func (s *ISteamUserService) GetPlayerSummariesBatch(params *PlayerSummariesParams) (*PlayerSummaries, *http.Response, error) {
+	return s.GetPlayerSummariesBatchWithContext(context.Background(), params)
+}
+
+// GetPlayerSummariesBatchWithContext is GetPlayerSummariesBatch with a context for cancellation
+func (s *ISteamUserService) GetPlayerSummariesBatchWithContext(ctx context.Context, params *PlayerSummariesParams) (*PlayerSummaries, *http.Response, error) {
+	players, missing, resp, err := s.playerSummaries(ctx, params.SteamIDs, params.Concurrency)
+
+	return &PlayerSummaries{
+		Players: players,
+		Missing: missing,
+	}, resp, err
+}
+
+// playerSummaries requests the ids in chunks, concurrency at a time. The
+// players are in the order of the chunks.
+func (s *ISteamUserService) playerSummaries(ctx context.Context, ids []SteamID, concurrency int) ([]Player, []SteamID, *http.Response, error) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := chunkSteamIDs(ids, maxSteamIDs)
+	results := make([][]Player, len(chunks))
+	resps := make([]*http.Response, len(chunks))
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	sem := make(chan struct{}, concurrency)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk []SteamID) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			response := new(summaryResponse)
+
+			var err error
+			resps[i], err = receive(ctx, s.sling.New().Path("GetPlayerSummaries/v2/").QueryStruct(struct {
+				SteamIDs string `url:"steamids"`
+			}{
+				SteamIDs: joinSteamIDs(chunk),
+			}), response, nil)
+			if err != nil {
+				// Stop the other requests, their errors are only from canceling
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+
+			results[i] = response.SResponse.Players
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	var players []Player
+	var resp *http.Response
+	for i := range chunks {
+		players = append(players, results[i]...)
+		resp = resps[i]
+	}
+
+	if firstErr != nil {
+		return players, nil, resp, firstErr
+	}
+
+	found := make(map[SteamID]bool, len(players))
+	for _, p := range players {
+		found[p.SteamID] = true
+	}
+
+	var missing []SteamID
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
 		}
 	}
 
-	response := new(summaryResponse)
+	return players, missing, resp, nil
+}
+
+// maxSteamIDs is the most SteamIDs Steam accepts in one request
+const maxSteamIDs = 100
 
-	resp, err := s.sling.New().Path("GetPlayerSummaries/v2/").QueryStruct(struct {
-		SteamIDs string `url:"steamids"`
-	}{
-		SteamIDs: pids,
-	}).ReceiveSuccess(response)
+// chunkSteamIDs splits ids into chunks of at most size
+func chunkSteamIDs(ids []SteamID, size int) [][]SteamID {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var chunks [][]SteamID
+	for size < len(ids) {
+		ids, chunks = ids[size:], append(chunks, ids[:size])
+	}
+
+	return append(chunks, ids)
+}
+
+// joinSteamIDs joins ids with commas
+func joinSteamIDs(ids []SteamID) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = id.String()
+	}
+
+	return strings.Join(s, ",")
+}
+
+type playerBansResponse struct {
+	Players []PlayerBan `json:"players"`
+}
+
+// PlayerBan is the ban status of a player from ISteamUserService.GetPlayerBans
+type PlayerBan struct {
+	SteamID          SteamID `json:"SteamId"`
+	CommunityBanned  bool    `json:"CommunityBanned"`
+	VACBanned        bool    `json:"VACBanned"`
+	NumberOfVACBans  int     `json:"NumberOfVACBans"`
+	DaysSinceLastBan int     `json:"DaysSinceLastBan"`
+	NumberOfGameBans int     `json:"NumberOfGameBans"`
+	EconomyBan       string  `json:"EconomyBan"` // "none", "probation" or "banned"
+}
+
+// GetPlayerBans returns the VAC, game, community and economy bans of
+// players. The ids are sent 100 at a time, the response is the last one.
+// https://developer.valvesoftware.com/wiki/Steam_Web_API#GetPlayerBans_.28v1.29
+func (s *ISteamUserService) GetPlayerBans(ids []SteamID) ([]PlayerBan, *http.Response, error) {
+	return s.GetPlayerBansWithContext(context.Background(), ids)
+}
+
+// GetPlayerBansWithContext is GetPlayerBans with a context for cancellation
+func (s *ISteamUserService) GetPlayerBansWithContext(ctx context.Context, ids []SteamID) ([]PlayerBan, *http.Response, error) {
+	var bans []PlayerBan
+	var resp *http.Response
+
+	for _, chunk := range chunkSteamIDs(ids, maxSteamIDs) {
+		response := new(playerBansResponse)
+
+		var err error
+		resp, err = receive(ctx, s.sling.New().Get("GetPlayerBans/v1/").QueryStruct(struct {
+			SteamIDs string `url:"steamids"`
+		}{
+			SteamIDs: joinSteamIDs(chunk),
+		}), response, nil)
+		if err != nil {
+			return bans, resp, err
+		}
+
+		bans = append(bans, response.Players...)
+	}
+
+	return bans, resp, nil
+}
+
+type userGroupListResponse struct {
+	Response struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+		Groups  []struct {
+			GID string `json:"gid"`
+		} `json:"groups"`
+	} `json:"response"`
+}
+
+// GetUserGroupList returns the groups a user is a member of, the members of
+// a group can be found with CommunityService.GroupMembers
+// https://wiki.teamfortress.com/wiki/WebAPI/GetUserGroupList
+func (s *ISteamUserService) GetUserGroupList(steamID SteamID) ([]SteamID, *http.Response, error) {
+	return s.GetUserGroupListWithContext(context.Background(), steamID)
+}
+
+// GetUserGroupListWithContext is GetUserGroupList with a context for cancellation
+func (s *ISteamUserService) GetUserGroupListWithContext(ctx context.Context, steamID SteamID) ([]SteamID, *http.Response, error) {
+	response := new(userGroupListResponse)
+
+	type params struct {
+		SteamID SteamID `url:"steamid"`
+	}
+
+	p := &params{
+		SteamID: steamID,
+	}
+
+	resp, err := receive(ctx, s.sling.New().Get("GetUserGroupList/v1/").QueryStruct(p), response, response)
+
+	var apiErr *APIError
+	if err == nil && !response.Response.Success {
+		apiErr = newAPIError(resp, 0, response.Response.Error)
+		err = apiErr
+	}
+	if errors.As(err, &apiErr) && response.Response.Error != "" {
+		apiErr.Message = response.Response.Error
+		if strings.Contains(strings.ToLower(response.Response.Error), "private") {
+			apiErr.Err = ErrPrivateProfile
+		}
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	groups := make([]SteamID, 0, len(response.Response.Groups))
+	for _, g := range response.Response.Groups {
+		id, err := groupIDFromGID(g.GID)
+		if err != nil {
+			return groups, resp, err
+		}
+		groups = append(groups, id)
+	}
+
+	return groups, resp, nil
+}
+
+// IsGroupMember checks if a user is a member of a group
+func (s *ISteamUserService) IsGroupMember(steamID, groupID SteamID) (bool, error) {
+	return s.IsGroupMemberWithContext(context.Background(), steamID, groupID)
+}
+
+// IsGroupMemberWithContext is IsGroupMember with a context for cancellation
+func (s *ISteamUserService) IsGroupMemberWithContext(ctx context.Context, steamID, groupID SteamID) (bool, error) {
+	groups, _, err := s.GetUserGroupListWithContext(ctx, steamID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, g := range groups {
+		if g == groupID {
+			return true, nil
+		}
+	}
 
-	return response.SResponse.Players, resp, err
+	return false, nil
 }
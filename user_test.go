@@ -2,6 +2,8 @@ package kettle
 
 import (
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,7 +40,7 @@ func TestISteamUserServiceGetFriendList(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Len(t, friends, 5)
 
-	assert.Equal(t, "76561197960412202", friends[0].SteamID)
+	assert.Equal(t, SteamID(76561197960412202), friends[0].SteamID)
 	assert.Equal(t, "friend", friends[0].Relationship)
 	assert.Equal(t, int64(1379557878), friends[0].FriendSince)
 }
@@ -74,7 +76,7 @@ func TestISteamUserServiceResolveVanityURL(t *testing.T) {
 	})
 
 	assert.Nil(t, err)
-	assert.Equal(t, "103582791431962114", resp.SteamID)
+	assert.Equal(t, SteamID(103582791431962114), resp.SteamID)
 	assert.Equal(t, 1, resp.Success)
 }
 
@@ -102,7 +104,7 @@ func TestISteamUserServiceGetPlayerSummaries(t *testing.T) {
 	})
 
 	client := NewClient(httpClient, "")
-	ids := []int64{
+	ids := []SteamID{
 		76561197960435530,
 		76561198006575550,
 		76561197977122693,
@@ -112,21 +114,148 @@ func TestISteamUserServiceGetPlayerSummaries(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Len(t, summaries, 3)
 
-	assert.Equal(t, "76561197977122693", summaries[0].SteamID)
-	assert.Equal(t, 3, summaries[0].CommunityVisibility)
-	assert.Equal(t, 1, summaries[0].ProfileState)
+	assert.Equal(t, SteamID(76561197977122693), summaries[0].SteamID)
+	assert.Equal(t, Public, summaries[0].CommunityVisibility)
+	assert.Equal(t, ProfileConfigured, summaries[0].ProfileState)
 	assert.Equal(t, "Viking", summaries[0].PersonaName)
 	assert.Equal(t, int64(1483736691), summaries[0].LastLogoff)
 	assert.Equal(t, "http://steamcommunity.com/id/Viking/", summaries[0].ProfileURL)
 	assert.Equal(t, "https://steamcdn-a.akamaihd.net/steamcommunity/public/images/avatars/8f/8fa7f1d5b92270783d6632a43cb0594f592839fa.jpg", summaries[0].Avatar)
 	assert.Equal(t, "https://steamcdn-a.akamaihd.net/steamcommunity/public/images/avatars/8f/8fa7f1d5b92270783d6632a43cb0594f592839fa_medium.jpg", summaries[0].AvatarMedium)
 	assert.Equal(t, "https://steamcdn-a.akamaihd.net/steamcommunity/public/images/avatars/8f/8fa7f1d5b92270783d6632a43cb0594f592839fa_full.jpg", summaries[0].AvatarFull)
-	assert.Equal(t, 1, summaries[0].PersonaState)
+	assert.Equal(t, Online, summaries[0].PersonaState)
 	assert.Equal(t, "Viking", summaries[0].RealName)
-	assert.Equal(t, "103582791429523489", summaries[0].PrimaryClanID)
+	assert.Equal(t, SteamID(103582791429523489), summaries[0].PrimaryClanID)
 	assert.Equal(t, int64(1122128079), summaries[0].TimeCreated)
-	assert.Equal(t, 0, summaries[0].PersonaStateFlags)
+	assert.Equal(t, PersonaStateFlags(0), summaries[0].PersonaStateFlags)
 	assert.Equal(t, "HITMAN™", summaries[0].GameTitle)
 	assert.Equal(t, "236870", summaries[0].GameID)
 	assert.Equal(t, "SE", summaries[0].LocCountryCode)
+	assert.True(t, summaries[0].IsPublic())
+	assert.True(t, summaries[0].InGame())
+}
+
+func TestISteamUserServiceGetPlayerBans(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamuser/getplayerbans.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	var chunks []int
+	mux.HandleFunc("/ISteamUser/GetPlayerBans/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		chunks = append(chunks, len(strings.Split(r.URL.Query().Get("steamids"), ",")))
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	ids := make([]SteamID, 150)
+	for i := range ids {
+		ids[i] = SteamIDFromAccountID(uint32(i + 1))
+	}
+
+	client := NewClient(httpClient, "")
+	bans, _, err := client.ISteamUserService.GetPlayerBans(ids)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{100, 50}, chunks)
+	assert.Len(t, bans, 4)
+
+	assert.Equal(t, SteamID(76561197960435530), bans[0].SteamID)
+	assert.Equal(t, false, bans[0].VACBanned)
+	assert.Equal(t, "none", bans[0].EconomyBan)
+
+	assert.Equal(t, SteamID(76561198006575550), bans[1].SteamID)
+	assert.Equal(t, true, bans[1].CommunityBanned)
+	assert.Equal(t, true, bans[1].VACBanned)
+	assert.Equal(t, 2, bans[1].NumberOfVACBans)
+	assert.Equal(t, 14, bans[1].DaysSinceLastBan)
+	assert.Equal(t, 1, bans[1].NumberOfGameBans)
+	assert.Equal(t, "probation", bans[1].EconomyBan)
+}
+
+func TestISteamUserServiceGetUserGroupList(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamuser/getusergrouplist.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUser/GetUserGroupList/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"key":     "",
+			"steamid": "76561197960287930",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	groups, _, err := client.ISteamUserService.GetUserGroupList(76561197960287930)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []SteamID{103582791429521412, 103582791431495565}, groups)
+
+	member, err := client.ISteamUserService.IsGroupMember(76561197960287930, 103582791429521412)
+	assert.Nil(t, err)
+	assert.True(t, member)
+}
+
+func TestISteamUserServiceGetPlayerSummariesBatch(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	var mu sync.Mutex
+	var chunks []int
+	mux.HandleFunc("/ISteamUser/GetPlayerSummaries/v2/", func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.URL.Query().Get("steamids"), ",")
+
+		mu.Lock()
+		chunks = append(chunks, len(ids))
+		mu.Unlock()
+
+		// Steam leaves out ids that don't exist
+		var players []string
+		for _, id := range ids {
+			if id != "76561197960265738" {
+				players = append(players, `{"steamid":"`+id+`"}`)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"response":{"players":[` + strings.Join(players, ",") + `]}}`))
+	})
+
+	ids := make([]SteamID, 250)
+	for i := range ids {
+		ids[i] = SteamIDFromAccountID(uint32(i + 1))
+	}
+
+	client := NewClient(httpClient, "")
+	summaries, resp, err := client.ISteamUserService.GetPlayerSummariesBatch(&PlayerSummariesParams{
+		SteamIDs:    ids,
+		Concurrency: 3,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.ElementsMatch(t, []int{100, 100, 50}, chunks)
+	assert.Len(t, summaries.Players, 249)
+	assert.Equal(t, ids[0], summaries.Players[0].SteamID)
+	assert.Equal(t, []SteamID{76561197960265738}, summaries.Missing)
 }
@@ -1,27 +1,36 @@
 package kettle
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/dghubble/sling"
 )
 
 // ISteamUserStatsService provides access to information about a user's stats.
 type ISteamUserStatsService struct {
-	sling *sling.Sling
+	sling    *sling.Sling
+	language string
 }
 
-func newISteamUserStatsService(sling *sling.Sling) *ISteamUserStatsService {
+func newISteamUserStatsService(sling *sling.Sling, language string) *ISteamUserStatsService {
 	return &ISteamUserStatsService{
-		sling: sling.Path("ISteamUserStats/"),
+		sling:    sling.Path("ISteamUserStats/"),
+		language: language,
 	}
 }
 
 // GetPlayerAchievementsParams are the parameters for ISteamUserStatsService.GetPlayerAchievements
 type GetPlayerAchievementsParams struct {
-	SteamID int64  `url:"steamid"`
-	AppID   int64  `url:"appid"`
-	Lang    string `url:"l,omitempty"`
+	SteamID SteamID `url:"steamid"`
+	AppID   int64   `url:"appid"`
+	Lang    string  `url:"l,omitempty"`
 }
 
 type playerAchievementsResp struct {
@@ -30,27 +39,105 @@ type playerAchievementsResp struct {
 
 // PlayerStats are returned from ISteamUserStatsService.GetPlayerAchievements
 type PlayerStats struct {
-	SteamID      string        `json:"steamID"`
+	SteamID      SteamID       `json:"steamID"`
 	GameName     string        `json:"gameName"`
 	Achievements []Achievement `json:"achievements"`
 	Success      bool          `json:"success"`
+	Error        string        `json:"error,omitempty"`
 }
 
-// Achievement is part of the PlayerStats returned from ISteamUserStatsService.GetPlayerAchievements
+// Achievement is part of the PlayerStats returned from ISteamUserStatsService.GetPlayerAchievements.
+// Name and Description are only set when a language is requested.
 type Achievement struct {
-	APIName  string `json:"apiname"`
-	Achieved int    `json:"achieved"`
+	APIName     string
+	Achieved    bool
+	UnlockTime  time.Time
+	Name        string
+	Description string
+}
+
+type achievement struct {
+	APIName     string `json:"apiname"`
+	Achieved    int    `json:"achieved"`
+	UnlockTime  int64  `json:"unlocktime"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// UnmarshalJSON reads achieved as a bool and unlocktime as a time.Time
+func (a *Achievement) UnmarshalJSON(b []byte) error {
+	var raw achievement
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	a.APIName = raw.APIName
+	a.Achieved = raw.Achieved != 0
+	a.UnlockTime = unixTime(raw.UnlockTime)
+	a.Name = raw.Name
+	a.Description = raw.Description
+	return nil
+}
+
+// MarshalJSON writes the achievement the way Steam sends it
+func (a Achievement) MarshalJSON() ([]byte, error) {
+	raw := achievement{
+		APIName:     a.APIName,
+		Name:        a.Name,
+		Description: a.Description,
+	}
+	if a.Achieved {
+		raw.Achieved = 1
+	}
+	if !a.UnlockTime.IsZero() {
+		raw.UnlockTime = a.UnlockTime.Unix()
+	}
+
+	return json.Marshal(raw)
 }
 
 // GetPlayerAchievements Returns a list of achievements for this user by app id
 // https://wiki.teamfortress.com/wiki/WebAPI/GetPlayerAchievements
 // https://developer.valvesoftware.com/wiki/Steam_Web_API#GetPlayerAchievements_.28v0001.29
 func (s *ISteamUserStatsService) GetPlayerAchievements(params *GetPlayerAchievementsParams) (*PlayerStats, *http.Response, error) {
+	return s.GetPlayerAchievementsWithContext(context.Background(), params)
+}
+
+// GetPlayerAchievementsWithContext is GetPlayerAchievements with a context for cancellation
+func (s *ISteamUserStatsService) GetPlayerAchievementsWithContext(ctx context.Context, params *GetPlayerAchievementsParams) (*PlayerStats, *http.Response, error) {
 	response := new(playerAchievementsResp)
 
-	resp, err := s.sling.New().Get("GetPlayerAchievements/v1/").QueryStruct(params).ReceiveSuccess(response)
+	p := *params
+	if p.Lang == "" {
+		p.Lang = s.language
+	}
+
+	resp, err := receive(ctx, s.sling.New().Get("GetPlayerAchievements/v1/").QueryStruct(&p), response, response)
+
+	if err == nil && !response.PlayerStats.Success {
+		err = newAPIError(resp, 0, response.PlayerStats.Error)
+	}
+
+	return &response.PlayerStats, resp, playerStatsError(err, response.PlayerStats.Error)
+}
+
+// playerStatsError adds the error message Steam sends for player stats to
+// err and maps the known ones to a sentinel
+func playerStatsError(err error, message string) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || message == "" {
+		return err
+	}
+
+	apiErr.Message = message
+	switch message {
+	case "Profile is not public":
+		apiErr.Err = ErrPrivateProfile
+	case "Requested app has no stats":
+		apiErr.Err = ErrNoStats
+	}
 
-	return &response.PlayerStats, resp, err
+	return err
 }
 
 type gameAchievementResp struct {
@@ -71,6 +158,11 @@ type GameAchievement struct {
 // https://wiki.teamfortress.com/wiki/WebAPI/GetGlobalAchievementPercentagesForApp
 // https://developer.valvesoftware.com/wiki/Steam_Web_API#GetGlobalAchievementPercentagesForApp_.28v0002.29
 func (s *ISteamUserStatsService) GetGlobalAchievementPercentagesForApp(gameid int64) ([]GameAchievement, *http.Response, error) {
+	return s.GetGlobalAchievementPercentagesForAppWithContext(context.Background(), gameid)
+}
+
+// GetGlobalAchievementPercentagesForAppWithContext is GetGlobalAchievementPercentagesForApp with a context for cancellation
+func (s *ISteamUserStatsService) GetGlobalAchievementPercentagesForAppWithContext(ctx context.Context, gameid int64) ([]GameAchievement, *http.Response, error) {
 	response := new(gameAchievementResp)
 
 	type params struct {
@@ -81,7 +173,7 @@ func (s *ISteamUserStatsService) GetGlobalAchievementPercentagesForApp(gameid in
 		GameID: gameid,
 	}
 
-	resp, err := s.sling.New().Get("GetGlobalAchievementPercentagesForApp/v2/").QueryStruct(p).ReceiveSuccess(response)
+	resp, err := receive(ctx, s.sling.New().Get("GetGlobalAchievementPercentagesForApp/v2/").QueryStruct(p), response, nil)
 
 	return response.AchievementPercentages.Achievements, resp, err
 }
@@ -90,7 +182,8 @@ type schemaResp struct {
 	Game GameSchema `json:"game"`
 }
 
-// GameSchema is the response for ISteamUserStatsService.GetSchemaForGame
+// GameSchema is the response for ISteamUserStatsService.GetSchemaForGame.
+// The display names and descriptions are in the requested language.
 type GameSchema struct {
 	GameName           string `json:"gameName"`
 	GameVersion        string `json:"gameVersion"`
@@ -103,7 +196,8 @@ type Stats struct {
 	Stats        []SchemaStat        `json:"stats"`
 }
 
-// SchemaAchievement is an achievement for Stats part of ISteamUserStatsService.GetSchemaForGame
+// SchemaAchievement is an achievement for Stats part of ISteamUserStatsService.GetSchemaForGame.
+// Description is empty for hidden achievements.
 type SchemaAchievement struct {
 	Name         string `json:"name"`
 	DefaultValue int    `json:"defaultvalue"`
@@ -114,19 +208,183 @@ type SchemaAchievement struct {
 	IconGray     string `json:"icongray"`
 }
 
-// SchemaStat is a stat for Stats part of ISteamUserStatsService.GetSchemaForGame
+// SchemaStat is a stat for Stats part of ISteamUserStatsService.GetSchemaForGame.
+// DefaultValue has a fraction for float stats.
 type SchemaStat struct {
-	Name         string `json:"name"`
-	DefaultValue int    `json:"defaultvalue"`
-	DisplayName  string `json:"displayName"`
+	Name         string  `json:"name"`
+	DefaultValue float64 `json:"defaultvalue"`
+	DisplayName  string  `json:"displayName"`
 }
 
 // GetSchemaForGame returns gamename, gameversion and availablegamestats
 // https://wiki.teamfortress.com/wiki/WebAPI/GetSchemaForGame
 // https://developer.valvesoftware.com/wiki/Steam_Web_API#GetSchemaForGame_.28v2.29
 func (s *ISteamUserStatsService) GetSchemaForGame(appid int64) (*GameSchema, *http.Response, error) {
+	return s.GetSchemaForGameWithContext(context.Background(), appid)
+}
+
+// GetSchemaForGameWithContext is GetSchemaForGame with a context for cancellation
+func (s *ISteamUserStatsService) GetSchemaForGameWithContext(ctx context.Context, appid int64) (*GameSchema, *http.Response, error) {
+	return s.GetSchemaForGameWithParamsContext(ctx, &GetSchemaForGameParams{AppID: appid})
+}
+
+// GetSchemaForGameParams are the parameters for ISteamUserStatsService.GetSchemaForGameWithParams
+// Lang is the client default if empty.
+type GetSchemaForGameParams struct {
+	AppID int64  `url:"appid"`
+	Lang  string `url:"l,omitempty"` // e.g. "german"
+}
+
+// GetSchemaForGameWithParams is GetSchemaForGame with the achievement and
+// stat names in a language
+func (s *ISteamUserStatsService) GetSchemaForGameWithParams(params *GetSchemaForGameParams) (*GameSchema, *http.Response, error) {
+	return s.GetSchemaForGameWithParamsContext(context.Background(), params)
+}
+
+// GetSchemaForGameWithParamsContext is GetSchemaForGameWithParams with a context for cancellation
+func (s *ISteamUserStatsService) GetSchemaForGameWithParamsContext(ctx context.Context, params *GetSchemaForGameParams) (*GameSchema, *http.Response, error) {
 	response := new(schemaResp)
 
+	p := *params
+	if p.Lang == "" {
+		p.Lang = s.language
+	}
+
+	resp, err := receive(ctx, s.sling.New().Get("GetSchemaForGame/v2/").QueryStruct(&p), response, nil)
+
+	return &response.Game, resp, err
+}
+
+// GetUserStatsForGameParams are the parameters for ISteamUserStatsService.GetUserStatsForGame
+type GetUserStatsForGameParams struct {
+	SteamID SteamID `url:"steamid"`
+	AppID   int64   `url:"appid"`
+}
+
+type userStatsResp struct {
+	PlayerStats UserStats `json:"playerstats"`
+}
+
+// UserStats is the response for ISteamUserStatsService.GetUserStatsForGame
+type UserStats struct {
+	SteamID      SteamID           `json:"steamID"`
+	GameName     string            `json:"gameName"`
+	Stats        []UserStat        `json:"stats"`
+	Achievements []UserAchievement `json:"achievements"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// UserStat is the value of a stat in UserStats, float stats can have a fraction
+type UserStat struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// UserAchievement is an achievement in UserStats, only unlocked ones are listed
+type UserAchievement struct {
+	Name     string
+	Achieved bool
+}
+
+type userAchievement struct {
+	Name     string `json:"name"`
+	Achieved int    `json:"achieved"`
+}
+
+// UnmarshalJSON reads achieved as a bool
+func (a *UserAchievement) UnmarshalJSON(b []byte) error {
+	var raw userAchievement
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	a.Name = raw.Name
+	a.Achieved = raw.Achieved != 0
+	return nil
+}
+
+// MarshalJSON writes the achievement the way Steam sends it
+func (a UserAchievement) MarshalJSON() ([]byte, error) {
+	raw := userAchievement{Name: a.Name}
+	if a.Achieved {
+		raw.Achieved = 1
+	}
+
+	return json.Marshal(raw)
+}
+
+// GetUserStatsForGame returns the stats and unlocked achievements of a user for an app
+// https://wiki.teamfortress.com/wiki/WebAPI/GetUserStatsForGame
+// https://developer.valvesoftware.com/wiki/Steam_Web_API#GetUserStatsForGame_.28v0002.29
+func (s *ISteamUserStatsService) GetUserStatsForGame(params *GetUserStatsForGameParams) (*UserStats, *http.Response, error) {
+	return s.GetUserStatsForGameWithContext(context.Background(), params)
+}
+
+// GetUserStatsForGameWithContext is GetUserStatsForGame with a context for cancellation
+func (s *ISteamUserStatsService) GetUserStatsForGameWithContext(ctx context.Context, params *GetUserStatsForGameParams) (*UserStats, *http.Response, error) {
+	response := new(userStatsResp)
+
+	resp, err := receive(ctx, s.sling.New().Get("GetUserStatsForGame/v2/").QueryStruct(params), response, response)
+
+	return &response.PlayerStats, resp, playerStatsError(err, response.PlayerStats.Error)
+}
+
+// StatValue is a stat from the GameSchema with the value a user has for it
+type StatValue struct {
+	SchemaStat
+	Value float64
+	// Set is false when the user has no value and Value is the default
+	Set bool
+}
+
+// WithSchema joins the stats with the stats in schema. Every stat in the
+// schema is returned in its order, stats the user has no value for have the
+// default value. Stats missing from the schema are added at the end.
+func (u *UserStats) WithSchema(schema *GameSchema) []StatValue {
+	values := make(map[string]float64, len(u.Stats))
+	for _, stat := range u.Stats {
+		values[stat.Name] = stat.Value
+	}
+
+	stats := make([]StatValue, 0, len(schema.AvailableGameStats.Stats))
+	known := make(map[string]bool, len(schema.AvailableGameStats.Stats))
+	for _, stat := range schema.AvailableGameStats.Stats {
+		known[stat.Name] = true
+
+		value, ok := values[stat.Name]
+		if !ok {
+			value = stat.DefaultValue
+		}
+		stats = append(stats, StatValue{SchemaStat: stat, Value: value, Set: ok})
+	}
+
+	for _, stat := range u.Stats {
+		if !known[stat.Name] {
+			stats = append(stats, StatValue{SchemaStat: SchemaStat{Name: stat.Name}, Value: stat.Value, Set: true})
+		}
+	}
+
+	return stats
+}
+
+type currentPlayersResp struct {
+	Response struct {
+		PlayerCount int `json:"player_count"`
+		Result      int `json:"result"`
+	} `json:"response"`
+}
+
+// GetNumberOfCurrentPlayers returns how many players are playing an app right now
+// https://wiki.teamfortress.com/wiki/WebAPI/GetNumberOfCurrentPlayers
+// https://partner.steamgames.com/doc/webapi/ISteamUserStats#GetNumberOfCurrentPlayers
+func (s *ISteamUserStatsService) GetNumberOfCurrentPlayers(appid int64) (int, *http.Response, error) {
+	return s.GetNumberOfCurrentPlayersWithContext(context.Background(), appid)
+}
+
+// GetNumberOfCurrentPlayersWithContext is GetNumberOfCurrentPlayers with a context for cancellation
+func (s *ISteamUserStatsService) GetNumberOfCurrentPlayersWithContext(ctx context.Context, appid int64) (int, *http.Response, error) {
+	response := new(currentPlayersResp)
+
 	type params struct {
 		AppID int64 `url:"appid"`
 	}
@@ -135,7 +393,380 @@ func (s *ISteamUserStatsService) GetSchemaForGame(appid int64) (*GameSchema, *ht
 		AppID: appid,
 	}
 
-	resp, err := s.sling.New().Get("GetSchemaForGame/v2/").QueryStruct(p).ReceiveSuccess(response)
+	resp, err := receive(ctx, s.sling.New().Get("GetNumberOfCurrentPlayers/v1/").QueryStruct(p), response, response)
 
-	return &response.Game, resp, err
+	if err == nil && response.Response.Result != 1 {
+		err = newAPIError(resp, response.Response.Result, "")
+	}
+
+	return response.Response.PlayerCount, resp, err
+}
+
+// PlayerCountSample is the number of players of an app at a time, Err is set
+// when the count couldn't be fetched
+type PlayerCountSample struct {
+	AppID   int64
+	Players int
+	Time    time.Time
+	Err     error
+}
+
+// PlayerCountSampler polls the number of current players of apps
+//
+//	sampler := client.ISteamUserStatsService.PlayerCountSampler([]int64{440, 570}, time.Minute)
+//	for sample := range sampler.Run(ctx) {
+//	}
+type PlayerCountSampler struct {
+	s        *ISteamUserStatsService
+	appIDs   []int64
+	interval time.Duration
+}
+
+// DefaultSampleInterval is the interval of a PlayerCountSampler created with
+// an interval of 0 or less
+const DefaultSampleInterval = time.Minute
+
+// PlayerCountSampler returns a sampler for appIDs polling every interval.
+// Requests go through the rate limit of the client so a round can take
+// longer than interval, rounds never overlap. DefaultSampleInterval is used
+// when interval isn't positive.
+func (s *ISteamUserStatsService) PlayerCountSampler(appIDs []int64, interval time.Duration) *PlayerCountSampler {
+	if interval <= 0 {
+		interval = DefaultSampleInterval
+	}
+
+	return &PlayerCountSampler{
+		s:        s,
+		appIDs:   append([]int64(nil), appIDs...),
+		interval: interval,
+	}
+}
+
+// Run samples every app right away and then every interval until ctx is
+// done, the channel is closed after that
+func (p *PlayerCountSampler) Run(ctx context.Context) <-chan PlayerCountSample {
+	samples := make(chan PlayerCountSample, len(p.appIDs))
+
+	go func() {
+		defer close(samples)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			for _, appID := range p.appIDs {
+				players, _, err := p.s.GetNumberOfCurrentPlayersWithContext(ctx, appID)
+				if ctx.Err() != nil {
+					return
+				}
+
+				sample := PlayerCountSample{
+					AppID:   appID,
+					Players: players,
+					Time:    time.Now().UTC(),
+					Err:     err,
+				}
+
+				select {
+				case samples <- sample:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return samples
+}
+
+// StatNames are the names of the stats for ISteamUserStatsService.GetGlobalStatsForGame
+type StatNames []string
+
+// EncodeValues encodes the names as name[0], name[1], ... with the count of
+// them in count
+func (n StatNames) EncodeValues(key string, v *url.Values) error {
+	for i, name := range n {
+		v.Set(key+"["+strconv.Itoa(i)+"]", name)
+	}
+	v.Set("count", strconv.Itoa(len(n)))
+
+	return nil
+}
+
+// GetGlobalStatsForGameParams are the parameters for
+// ISteamUserStatsService.GetGlobalStatsForGame. History per day is only
+// returned when StartDate and EndDate are set.
+type GetGlobalStatsForGameParams struct {
+	AppID     int64     `url:"appid"`
+	Names     StatNames `url:"name,omitempty"`
+	StartDate time.Time `url:"startdate,omitempty,unix"`
+	EndDate   time.Time `url:"enddate,omitempty,unix"`
+}
+
+type globalStatsResp struct {
+	Response struct {
+		GlobalStats map[string]GlobalStat `json:"globalstats"`
+		Result      int                   `json:"result"`
+		Error       string                `json:"error"`
+	} `json:"response"`
+}
+
+// GlobalStat is the total of a stat over all players from
+// ISteamUserStatsService.GetGlobalStatsForGame, totals of float stats have a
+// fraction
+type GlobalStat struct {
+	Total   float64
+	History []GlobalStatDay
+}
+
+type globalStat struct {
+	Total   json.RawMessage `json:"total"`
+	History []GlobalStatDay `json:"history,omitempty"`
+}
+
+// UnmarshalJSON reads the total, Steam sends it as a string
+func (g *GlobalStat) UnmarshalJSON(b []byte) error {
+	var raw globalStat
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	total, err := statTotal(raw.Total)
+	if err != nil {
+		return err
+	}
+
+	g.Total = total
+	g.History = raw.History
+	return nil
+}
+
+// MarshalJSON writes the stat the way Steam sends it
+func (g GlobalStat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(globalStat{
+		Total:   formatStatTotal(g.Total),
+		History: g.History,
+	})
+}
+
+// GlobalStatDay is the total of a stat for one day
+type GlobalStatDay struct {
+	Date  time.Time
+	Total float64
+}
+
+type globalStatDay struct {
+	Date  int64           `json:"date"`
+	Total json.RawMessage `json:"total"`
+}
+
+// UnmarshalJSON reads the date and total, Steam sends the total as a string
+func (d *GlobalStatDay) UnmarshalJSON(b []byte) error {
+	var raw globalStatDay
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	total, err := statTotal(raw.Total)
+	if err != nil {
+		return err
+	}
+
+	d.Date = unixTime(raw.Date)
+	d.Total = total
+	return nil
+}
+
+// MarshalJSON writes the day the way Steam sends it
+func (d GlobalStatDay) MarshalJSON() ([]byte, error) {
+	var date int64
+	if !d.Date.IsZero() {
+		date = d.Date.Unix()
+	}
+
+	return json.Marshal(globalStatDay{
+		Date:  date,
+		Total: formatStatTotal(d.Total),
+	})
+}
+
+// statTotal decodes a total sent as a string or a number, unlike flexInt
+// anything that isn't a number is an error
+func statTotal(raw json.RawMessage) (float64, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, nil
+	}
+
+	s := string(raw)
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return 0, err
+		}
+	}
+
+	total, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("kettle: %q is not a number", s)
+	}
+
+	return total, nil
+}
+
+func formatStatTotal(total float64) json.RawMessage {
+	return json.RawMessage(strconv.Quote(strconv.FormatFloat(total, 'f', -1, 64)))
+}
+
+// GetGlobalStatsForGame returns the totals of aggregated stats over all players
+// https://wiki.teamfortress.com/wiki/WebAPI/GetGlobalStatsForGame
+// https://partner.steamgames.com/doc/webapi/ISteamUserStats#GetGlobalStatsForGame
+func (s *ISteamUserStatsService) GetGlobalStatsForGame(params *GetGlobalStatsForGameParams) (map[string]GlobalStat, *http.Response, error) {
+	return s.GetGlobalStatsForGameWithContext(context.Background(), params)
+}
+
+// GetGlobalStatsForGameWithContext is GetGlobalStatsForGame with a context for cancellation
+func (s *ISteamUserStatsService) GetGlobalStatsForGameWithContext(ctx context.Context, params *GetGlobalStatsForGameParams) (map[string]GlobalStat, *http.Response, error) {
+	response := new(globalStatsResp)
+
+	resp, err := receive(ctx, s.sling.New().Get("GetGlobalStatsForGame/v1/").QueryStruct(params), response, response)
+
+	if err == nil && response.Response.Result != 1 {
+		err = newAPIError(resp, response.Response.Result, response.Response.Error)
+	}
+
+	return response.Response.GlobalStats, resp, err
+}
+
+// AchievementReport is the progress of a player on the achievements of an app
+// from ISteamUserStatsService.AchievementReport
+type AchievementReport struct {
+	SteamID      SteamID
+	GameName     string
+	Achievements []AchievementProgress
+	Unlocked     int
+	Total        int
+	// Completion is the percentage of achievements unlocked, 0 to 100
+	Completion float64
+	// RarestUnlocked is the unlocked achievement with the lowest global
+	// percentage, nil when nothing is unlocked
+	RarestUnlocked *AchievementProgress
+}
+
+// AchievementProgress is an achievement of an AchievementReport
+type AchievementProgress struct {
+	Name        string
+	DisplayName string
+	Description string
+	Icon        string
+	IconGray    string
+	Hidden      bool
+	Unlocked    bool
+	UnlockTime  time.Time
+	// GlobalPercent is the percentage of players that unlocked it
+	GlobalPercent float64
+}
+
+// AchievementReport joins GetSchemaForGame, GetPlayerAchievements and
+// GetGlobalAchievementPercentagesForApp into one view of the achievements of
+// a player. Achievements are in the order of the schema.
+func (s *ISteamUserStatsService) AchievementReport(params *GetPlayerAchievementsParams) (*AchievementReport, error) {
+	return s.AchievementReportWithContext(context.Background(), params)
+}
+
+// AchievementReportWithContext is AchievementReport with a context for cancellation
+func (s *ISteamUserStatsService) AchievementReportWithContext(ctx context.Context, params *GetPlayerAchievementsParams) (*AchievementReport, error) {
+	schema, _, err := s.GetSchemaForGameWithParamsContext(ctx, &GetSchemaForGameParams{
+		AppID: params.AppID,
+		Lang:  params.Lang,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	player, _, err := s.GetPlayerAchievementsWithContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	global, _, err := s.GetGlobalAchievementPercentagesForAppWithContext(ctx, params.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAchievementReport(schema, player, global), nil
+}
+
+func newAchievementReport(schema *GameSchema, player *PlayerStats, global []GameAchievement) *AchievementReport {
+	percents := make(map[string]float64, len(global))
+	for _, a := range global {
+		percents[a.Name] = a.Percent
+	}
+
+	unlocked := make(map[string]Achievement, len(player.Achievements))
+	for _, a := range player.Achievements {
+		unlocked[a.APIName] = a
+	}
+
+	report := &AchievementReport{
+		SteamID:  player.SteamID,
+		GameName: player.GameName,
+	}
+	if report.GameName == "" {
+		report.GameName = schema.GameName
+	}
+
+	known := make(map[string]bool, len(schema.AvailableGameStats.Achievements))
+	for _, a := range schema.AvailableGameStats.Achievements {
+		known[a.Name] = true
+		report.Achievements = append(report.Achievements, AchievementProgress{
+			Name:          a.Name,
+			DisplayName:   a.DisplayName,
+			Description:   a.Description,
+			Icon:          a.Icon,
+			IconGray:      a.IconGray,
+			Hidden:        a.Hidden != 0,
+			GlobalPercent: percents[a.Name],
+		})
+	}
+	for _, a := range player.Achievements {
+		if !known[a.APIName] {
+			report.Achievements = append(report.Achievements, AchievementProgress{
+				Name:          a.APIName,
+				DisplayName:   a.Name,
+				Description:   a.Description,
+				GlobalPercent: percents[a.APIName],
+			})
+		}
+	}
+
+	for i := range report.Achievements {
+		a := &report.Achievements[i]
+		if p, ok := unlocked[a.Name]; ok && p.Achieved {
+			a.Unlocked = true
+			a.UnlockTime = p.UnlockTime
+		}
+	}
+
+	report.Total = len(report.Achievements)
+	for i := range report.Achievements {
+		a := &report.Achievements[i]
+		if !a.Unlocked {
+			continue
+		}
+
+		report.Unlocked++
+		if report.RarestUnlocked == nil || a.GlobalPercent < report.RarestUnlocked.GlobalPercent {
+			report.RarestUnlocked = a
+		}
+	}
+	if report.Total > 0 {
+		report.Completion = float64(report.Unlocked) / float64(report.Total) * 100
+	}
+
+	return report
 }
@@ -1,8 +1,12 @@
 package kettle
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -38,12 +42,13 @@ func TestISteamUserStatsServiceGetPlayerAchievements(t *testing.T) {
 	})
 
 	assert.Nil(t, err)
-	assert.Equal(t, "76561198006575550", resp.SteamID)
+	assert.Equal(t, SteamID(76561198006575550), resp.SteamID)
 	assert.Equal(t, "Dungeons of Dredmor", resp.GameName)
 
 	assert.Len(t, resp.Achievements, 5)
 	assert.Equal(t, "ACHIEVEMENT_KILL_DREDMOR_EASY", resp.Achievements[0].APIName)
-	assert.Equal(t, 1, resp.Achievements[0].Achieved)
+	assert.True(t, resp.Achievements[0].Achieved)
+	assert.False(t, resp.Achievements[1].Achieved)
 
 	assert.True(t, resp.Success)
 }
@@ -121,6 +126,547 @@ func TestISteamUserStatsServiceGetSchemaForGame(t *testing.T) {
 
 	assert.Len(t, gameSchema.AvailableGameStats.Stats, 5)
 	assert.Equal(t, "STAT_VICTORIES", gameSchema.AvailableGameStats.Stats[0].Name)
-	assert.Equal(t, 0, gameSchema.AvailableGameStats.Stats[0].DefaultValue)
+	assert.Equal(t, float64(0), gameSchema.AvailableGameStats.Stats[0].DefaultValue)
 	assert.Equal(t, "Victories", gameSchema.AvailableGameStats.Stats[0].DisplayName)
 }
+
+func TestISteamUserStatsServiceGetUserStatsForGame(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamuserstats/getuserstatsforgame.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetUserStatsForGame/v2/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"key":     "",
+			"steamid": "76561198006575550",
+			"appid":   "98800",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	stats, _, err := client.ISteamUserStatsService.GetUserStatsForGame(&GetUserStatsForGameParams{
+		SteamID: 76561198006575550,
+		AppID:   98800,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, SteamID(76561198006575550), stats.SteamID)
+	assert.Equal(t, "Dungeons of Dredmor", stats.GameName)
+	assert.Len(t, stats.Stats, 3)
+	assert.Equal(t, float64(1572), stats.Stats[1].Value)
+	assert.Equal(t, 12.5, stats.Stats[2].Value)
+	assert.Len(t, stats.Achievements, 1)
+	assert.Equal(t, "ACHIEVEMENT_KILL_DREDMOR_EASY", stats.Achievements[0].Name)
+	assert.True(t, stats.Achievements[0].Achieved)
+
+	b, err := json.Marshal(stats)
+	assert.Nil(t, err)
+	again := new(UserStats)
+	assert.Nil(t, json.Unmarshal(b, again))
+	assert.Equal(t, stats, again)
+}
+
+func TestUserStatsWithSchema(t *testing.T) {
+	t.Parallel()
+
+	b, err := getTestFile("./json/isteamuserstats/getschemaforgame.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := new(schemaResp)
+	if err := json.Unmarshal(b, schema); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := &UserStats{Stats: []UserStat{
+		{Name: "STAT_MONSTERS_KILLED", Value: 1572},
+		{Name: "STAT_OLD_DISTANCE", Value: 12.5},
+	}}
+
+	joined := stats.WithSchema(&schema.Game)
+	assert.Len(t, joined, 6)
+
+	assert.Equal(t, "Victories", joined[0].DisplayName)
+	assert.Equal(t, float64(0), joined[0].Value)
+	assert.False(t, joined[0].Set)
+
+	assert.Equal(t, "Monsters Killed", joined[3].DisplayName)
+	assert.Equal(t, float64(1572), joined[3].Value)
+	assert.True(t, joined[3].Set)
+
+	assert.Equal(t, "STAT_OLD_DISTANCE", joined[5].Name)
+	assert.Equal(t, "", joined[5].DisplayName)
+	assert.Equal(t, 12.5, joined[5].Value)
+}
+
+func TestISteamUserStatsServiceGetUserStatsForGamePrivate(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetUserStatsForGame/v2/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"playerstats":{"error":"Profile is not public"}}`))
+	})
+
+	client := NewClient(httpClient, "")
+	_, _, err := client.ISteamUserStatsService.GetUserStatsForGame(&GetUserStatsForGameParams{
+		SteamID: 76561198006575550,
+		AppID:   98800,
+	})
+
+	assert.True(t, errors.Is(err, ErrPrivateProfile))
+}
+
+func TestISteamUserStatsServiceGetNumberOfCurrentPlayers(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamuserstats/getnumberofcurrentplayers.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetNumberOfCurrentPlayers/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"key":   "",
+			"appid": "440",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	players, _, err := client.ISteamUserStatsService.GetNumberOfCurrentPlayers(440)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 51243, players)
+}
+
+func TestISteamUserStatsServiceGetNumberOfCurrentPlayersNotFound(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetNumberOfCurrentPlayers/v1/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"response":{"result":42}}`))
+	})
+
+	client := NewClient(httpClient, "")
+	_, _, err := client.ISteamUserStatsService.GetNumberOfCurrentPlayers(1)
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestPlayerCountSampler(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetNumberOfCurrentPlayers/v1/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("appid") == "1" {
+			w.Write([]byte(`{"response":{"result":42}}`))
+			return
+		}
+		w.Write([]byte(`{"response":{"player_count":` + r.URL.Query().Get("appid") + `,"result":1}}`))
+	})
+
+	client := NewClient(httpClient, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sampler := client.ISteamUserStatsService.PlayerCountSampler([]int64{440, 1, 570}, 10*time.Millisecond)
+	samples := sampler.Run(ctx)
+
+	var got []PlayerCountSample
+	for sample := range samples {
+		got = append(got, sample)
+		if len(got) == 6 {
+			cancel()
+		}
+	}
+
+	assert.True(t, len(got) >= 6)
+	for i, sample := range got[:6] {
+		assert.Equal(t, []int64{440, 1, 570}[i%3], sample.AppID)
+		assert.False(t, sample.Time.IsZero())
+	}
+	assert.Equal(t, 440, got[0].Players)
+	assert.True(t, errors.Is(got[1].Err, ErrUnsuccessful))
+	assert.Equal(t, 570, got[5].Players)
+}
+
+func TestPlayerCountSamplerZeroInterval(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetNumberOfCurrentPlayers/v1/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"response":{"player_count":5,"result":1}}`))
+	})
+
+	client := NewClient(httpClient, "")
+	for _, interval := range []time.Duration{0, -time.Second} {
+		sampler := client.ISteamUserStatsService.PlayerCountSampler([]int64{1}, interval)
+		assert.Equal(t, DefaultSampleInterval, sampler.interval)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		samples := sampler.Run(ctx)
+		sample := <-samples
+		assert.Equal(t, 5, sample.Players)
+
+		// The sampler stops and closes the channel once ctx is done
+		cancel()
+		for range samples {
+		}
+	}
+}
+
+func TestISteamUserStatsServiceGetGlobalStatsForGame(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamuserstats/getglobalstatsforgame.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetGlobalStatsForGame/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"key":       "",
+			"appid":     "17740",
+			"count":     "2",
+			"name[0]":   "global.map.emp_isle",
+			"name[1]":   "global.kills",
+			"startdate": "1592697600",
+			"enddate":   "1592870400",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	stats, _, err := client.ISteamUserStatsService.GetGlobalStatsForGame(&GetGlobalStatsForGameParams{
+		AppID:     17740,
+		Names:     StatNames{"global.map.emp_isle", "global.kills"},
+		StartDate: time.Date(2020, time.June, 21, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2020, time.June, 23, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, float64(96217832), stats["global.kills"].Total)
+	assert.Len(t, stats["global.kills"].History, 2)
+	assert.Equal(t, time.Date(2020, time.June, 22, 0, 0, 0, 0, time.UTC), stats["global.kills"].History[1].Date)
+	assert.Equal(t, float64(49921), stats["global.kills"].History[1].Total)
+	assert.Equal(t, float64(2390741), stats["global.map.emp_isle"].Total)
+}
+
+func TestGlobalStatJSON(t *testing.T) {
+	t.Parallel()
+
+	stat := new(GlobalStat)
+	err := json.Unmarshal([]byte(`{"total":"12.75","history":[{"date":1592697600,"total":"0.5"}]}`), stat)
+	assert.Nil(t, err)
+	assert.Equal(t, 12.75, stat.Total)
+	assert.Equal(t, 0.5, stat.History[0].Total)
+
+	b, err := json.Marshal(stat)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"total":"12.75","history":[{"date":1592697600,"total":"0.5"}]}`, string(b))
+
+	again := new(GlobalStat)
+	assert.Nil(t, json.Unmarshal(b, again))
+	assert.Equal(t, stat, again)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"total":"18+"}`), new(GlobalStat)))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"date":1,"total":"abc"}`), new(GlobalStatDay)))
+}
+
+func TestISteamUserStatsServiceGetGlobalStatsForGameUnsuccessful(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetGlobalStatsForGame/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertQuery(t, map[string]string{
+			"key":     "",
+			"appid":   "17740",
+			"count":   "1",
+			"name[0]": "global.missing",
+		}, r)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"response":{"result":8,"error":"Failed to get global stats"}}`))
+	})
+
+	client := NewClient(httpClient, "")
+	_, _, err := client.ISteamUserStatsService.GetGlobalStatsForGame(&GetGlobalStatsForGameParams{
+		AppID: 17740,
+		Names: StatNames{"global.missing"},
+	})
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 8, apiErr.Success)
+	assert.Equal(t, "Failed to get global stats", apiErr.Message)
+	assert.True(t, errors.Is(err, ErrUnsuccessful))
+}
+
+func TestISteamUserStatsServiceAchievementReport(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	files := map[string]string{
+		"/ISteamUserStats/GetSchemaForGame/v2/":                      "./json/isteamuserstats/getschemaforgame.json",
+		"/ISteamUserStats/GetPlayerAchievements/v1/":                 "./json/isteamuserstats/getplayerachievements.unlocktime.json",
+		"/ISteamUserStats/GetGlobalAchievementPercentagesForApp/v2/": "./json/isteamuserstats/getglobalachievementpercentagesforapp.report.json",
+	}
+	for path, filePath := range files {
+		filePath := filePath
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, "GET", r)
+
+			b, err := getTestFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to open testfile %s", filePath)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(b)
+		})
+	}
+
+	client := NewClient(httpClient, "")
+	report, err := client.ISteamUserStatsService.AchievementReport(&GetPlayerAchievementsParams{
+		SteamID: 76561198006575550,
+		AppID:   98800,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, SteamID(76561198006575550), report.SteamID)
+	assert.Equal(t, "Dungeons of Dredmor", report.GameName)
+	assert.Len(t, report.Achievements, 5)
+	assert.Equal(t, 3, report.Unlocked)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, float64(60), report.Completion)
+
+	first := report.Achievements[0]
+	assert.Equal(t, "ACHIEVEMENT_KILL_DREDMOR_EASY", first.Name)
+	assert.Equal(t, "Dread Less", first.DisplayName)
+	assert.Equal(t, "Kill Lord Dredmor on Elvishly Easy Mode.", first.Description)
+	assert.False(t, first.Hidden)
+	assert.True(t, first.Unlocked)
+	assert.Equal(t, time.Unix(1592750123, 0).UTC(), first.UnlockTime)
+	assert.Equal(t, 9.75, first.GlobalPercent)
+
+	assert.False(t, report.Achievements[2].Unlocked)
+	assert.True(t, report.Achievements[2].UnlockTime.IsZero())
+
+	if assert.NotNil(t, report.RarestUnlocked) {
+		assert.Equal(t, "ACHIEVEMENT_KILL_DREDMOR_EASY", report.RarestUnlocked.Name)
+	}
+}
+
+func TestNewAchievementReportNothingUnlocked(t *testing.T) {
+	t.Parallel()
+
+	report := newAchievementReport(&GameSchema{}, &PlayerStats{}, nil)
+
+	assert.Equal(t, 0, report.Total)
+	assert.Equal(t, float64(0), report.Completion)
+	assert.Nil(t, report.RarestUnlocked)
+}
+
+func TestISteamUserStatsServiceGetPlayerAchievementsLocalized(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamuserstats/getplayerachievements.localized.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetPlayerAchievements/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"key":     "",
+			"steamid": "76561198006575550",
+			"appid":   "98800",
+			"l":       "french",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	resp, _, err := client.ISteamUserStatsService.GetPlayerAchievements(&GetPlayerAchievementsParams{
+		SteamID: 76561198006575550,
+		AppID:   98800,
+		Lang:    "french",
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, resp.Achievements, 2)
+
+	assert.True(t, resp.Achievements[0].Achieved)
+	assert.Equal(t, time.Date(2020, time.June, 21, 14, 35, 23, 0, time.UTC), resp.Achievements[0].UnlockTime)
+	assert.Equal(t, "Moins d'effroi", resp.Achievements[0].Name)
+	assert.Equal(t, "Tuez Lord Dredmor en mode Elfiquement facile.", resp.Achievements[0].Description)
+
+	assert.False(t, resp.Achievements[1].Achieved)
+	assert.True(t, resp.Achievements[1].UnlockTime.IsZero())
+}
+
+func TestPlayerStatsJSON(t *testing.T) {
+	t.Parallel()
+
+	b, err := getTestFile("./json/isteamuserstats/getplayerachievements.localized.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := new(playerAchievementsResp)
+	if err := json.Unmarshal(b, response); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err = json.Marshal(response.PlayerStats)
+	assert.Nil(t, err)
+
+	again := new(PlayerStats)
+	assert.Nil(t, json.Unmarshal(b, again))
+	assert.Equal(t, &response.PlayerStats, again)
+	assert.Contains(t, string(b), `"achieved":1,"unlocktime":1592750123`)
+}
+
+func TestISteamUserStatsServiceGetPlayerAchievementsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status  int
+		message string
+		err     error
+	}{
+		{http.StatusForbidden, "Profile is not public", ErrPrivateProfile},
+		{http.StatusBadRequest, "Requested app has no stats", ErrNoStats},
+		{http.StatusOK, "Requested app has no stats", ErrNoStats},
+	}
+
+	for _, test := range tests {
+		httpClient, mux, server := testServer()
+
+		status, message := test.status, test.message
+		mux.HandleFunc("/ISteamUserStats/GetPlayerAchievements/v1/", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"playerstats":{"error":"` + message + `","success":false}}`))
+		})
+
+		client := NewClient(httpClient, "")
+		_, _, err := client.ISteamUserStatsService.GetPlayerAchievements(&GetPlayerAchievementsParams{
+			SteamID: 76561198006575550,
+			AppID:   98800,
+		})
+		server.Close()
+
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr), test.message)
+		assert.Equal(t, test.message, apiErr.Message)
+		assert.True(t, errors.Is(err, test.err), test.message)
+	}
+}
+
+func TestISteamUserStatsServiceGetSchemaForGameWithParams(t *testing.T) {
+	t.Parallel()
+	const filePath = "./json/isteamuserstats/getschemaforgame.german.json"
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetSchemaForGame/v2/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+
+		assertQuery(t, map[string]string{
+			"key":   "",
+			"appid": "98800",
+			"l":     "german",
+		}, r)
+
+		b, err := getTestFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to open testfile %s", filePath)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	client := NewClient(httpClient, "")
+	gameSchema, _, err := client.ISteamUserStatsService.GetSchemaForGameWithParams(&GetSchemaForGameParams{
+		AppID: 98800,
+		Lang:  "german",
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, gameSchema.AvailableGameStats.Achievements, 2)
+	assert.Equal(t, "Weniger Furcht", gameSchema.AvailableGameStats.Achievements[0].DisplayName)
+	assert.Equal(t, "Töte Lord Dredmor im Elfisch-Einfach-Modus.", gameSchema.AvailableGameStats.Achievements[0].Description)
+	assert.Equal(t, 1, gameSchema.AvailableGameStats.Achievements[1].Hidden)
+	assert.Equal(t, "", gameSchema.AvailableGameStats.Achievements[1].Description)
+
+	assert.Len(t, gameSchema.AvailableGameStats.Stats, 2)
+	assert.Equal(t, "Gelaufene Strecke", gameSchema.AvailableGameStats.Stats[1].DisplayName)
+	assert.Equal(t, 0.5, gameSchema.AvailableGameStats.Stats[1].DefaultValue)
+}
+
+func TestISteamUserStatsServiceGetSchemaForGameClientLanguage(t *testing.T) {
+	t.Parallel()
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/ISteamUserStats/GetSchemaForGame/v2/", func(w http.ResponseWriter, r *http.Request) {
+		assertQuery(t, map[string]string{
+			"key":   "",
+			"appid": "98800",
+			"l":     "german",
+		}, r)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"game":{}}`))
+	})
+
+	client := New(WithHTTPClient(httpClient), WithLanguage("german"))
+	_, _, err := client.ISteamUserStatsService.GetSchemaForGame(98800)
+
+	assert.Nil(t, err)
+}